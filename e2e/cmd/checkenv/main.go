@@ -0,0 +1,168 @@
+// Copyright Contributors to the Open Cluster Management project
+
+// checkenv verifies the environment prerequisites of a planned run
+// before any spec executes: required variables, kubeconfig files, cloud
+// credentials, hub reachability and reporting tokens. It prints a
+// pass/fail checklist and exits non-zero when anything a selected label
+// needs is missing.
+//
+// Usage:
+//
+//	checkenv -labels aws,import,polarion
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/tools/clientcmd"
+
+	"github.com/stolostron/ai-test-gen/e2e/pkg/config"
+	"github.com/stolostron/ai-test-gen/e2e/pkg/credentials"
+)
+
+// check is one prerequisite. It runs when the requested label set
+// intersects its labels; an empty label list means it always runs.
+type check struct {
+	name   string
+	labels []string
+	run    func(ctx context.Context) error
+}
+
+func main() {
+	labelsFlag := flag.String("labels", "", "comma-separated labels of the planned run (e.g. aws,import,polarion)")
+	timeoutFlag := flag.Duration("timeout", 2*time.Minute, "overall timeout for all checks")
+	flag.Parse()
+
+	requested := map[string]bool{}
+	for _, label := range strings.Split(*labelsFlag, ",") {
+		if label = strings.TrimSpace(strings.ToLower(label)); label != "" {
+			requested[label] = true
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), *timeoutFlag)
+	defer cancel()
+
+	failed := 0
+	for _, candidate := range allChecks() {
+		if !checkSelected(candidate, requested) {
+			continue
+		}
+		if err := candidate.run(ctx); err != nil {
+			fmt.Printf("FAIL %-40s %v\n", candidate.name, err)
+			failed++
+		} else {
+			fmt.Printf("ok   %s\n", candidate.name)
+		}
+	}
+	if failed > 0 {
+		fmt.Printf("\n%d prerequisite(s) missing\n", failed)
+		os.Exit(1)
+	}
+	fmt.Println("\nenvironment is ready")
+}
+
+func checkSelected(candidate check, requested map[string]bool) bool {
+	if len(candidate.labels) == 0 {
+		return true
+	}
+	for _, label := range candidate.labels {
+		if requested[label] {
+			return true
+		}
+	}
+	return false
+}
+
+func allChecks() []check {
+	return []check{
+		{name: "hub kubeconfig configured", run: checkHubConfigured},
+		{name: "hub API server reachable", run: checkHubReachable},
+		{name: "aws credentials", labels: []string{"aws", "eks", "rosa", "hypershift"}, run: credentialCheck("aws")},
+		{name: "gcp credentials", labels: []string{"gcp", "gke"}, run: credentialCheck("gcp")},
+		{name: "azure credentials", labels: []string{"azure", "aks"}, run: credentialCheck("azure")},
+		{name: "polarion credentials", labels: []string{"polarion"}, run: envVarsCheck("POLARION_URL", "POLARION_TOKEN")},
+		{name: "jira credentials", labels: []string{"jira"}, run: envVarsCheck("JIRA_URL", "JIRA_TOKEN")},
+		{name: "reportportal credentials", labels: []string{"reportportal"}, run: envVarsCheck("RP_ENDPOINT", "RP_TOKEN", "RP_PROJECT")},
+	}
+}
+
+func checkHubConfigured(_ context.Context) error {
+	hubs, err := config.LoadHubs()
+	if err != nil {
+		return err
+	}
+	for _, hub := range hubs {
+		if _, err := os.Stat(hub.Kubeconfig); err != nil {
+			return fmt.Errorf("kubeconfig of %s: %s does not exist", hub.Name, hub.Kubeconfig)
+		}
+	}
+	return nil
+}
+
+func checkHubReachable(ctx context.Context) error {
+	hubs, err := config.LoadHubs()
+	if err != nil {
+		return err
+	}
+	for _, hub := range hubs {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		restConfig, err := clientcmd.BuildConfigFromFlags("", hub.Kubeconfig)
+		if err != nil {
+			return fmt.Errorf("%s: %w", hub.Name, err)
+		}
+		restConfig.Timeout = 20 * time.Second
+		discoveryClient, err := discovery.NewDiscoveryClientForConfig(restConfig)
+		if err != nil {
+			return fmt.Errorf("%s: %w", hub.Name, err)
+		}
+		if _, err := discoveryClient.ServerVersion(); err != nil {
+			return fmt.Errorf("%s is unreachable: %w", hub.Name, err)
+		}
+	}
+	return nil
+}
+
+// credentialCheck verifies a cloud credential set resolves through the
+// configured source (Vault when set, environment otherwise).
+func credentialCheck(cloud string) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		var source credentials.Source
+		vaultSource, err := credentials.NewVaultSourceFromEnv()
+		if err != nil {
+			return err
+		}
+		if vaultSource != nil {
+			source = vaultSource
+		} else {
+			source = &credentials.EnvSource{}
+		}
+		if _, err := source.Fetch(ctx, cloud); err != nil {
+			return err
+		}
+		return nil
+	}
+}
+
+func envVarsCheck(names ...string) func(ctx context.Context) error {
+	return func(_ context.Context) error {
+		var missing []string
+		for _, name := range names {
+			if os.Getenv(name) == "" {
+				missing = append(missing, name)
+			}
+		}
+		if len(missing) > 0 {
+			return fmt.Errorf("missing %s", strings.Join(missing, ", "))
+		}
+		return nil
+	}
+}
@@ -0,0 +1,103 @@
+// Copyright Contributors to the Open Cluster Management project
+
+// cleanup deletes everything a run left on the hub, found by the owner
+// and run ID labels every helper stamps on created resources.
+//
+// Usage:
+//
+//	cleanup --run-id clc-20260830-x7k2q [--dry-run]
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/tools/clientcmd"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/stolostron/ai-test-gen/e2e/pkg/diagnostics"
+)
+
+// cleanupKinds lists what a run can leave behind, ordered so dependents
+// go before the namespaces that hold them. Unregistered CRDs (e.g. no
+// Hive on this hub) are skipped.
+var cleanupKinds = []schema.GroupVersionKind{
+	{Group: "cluster.open-cluster-management.io", Version: "v1", Kind: "ManagedCluster"},
+	{Group: "cluster.open-cluster-management.io", Version: "v1beta1", Kind: "Placement"},
+	{Group: "cluster.open-cluster-management.io", Version: "v1beta2", Kind: "ManagedClusterSet"},
+	{Group: "hive.openshift.io", Version: "v1", Kind: "ClusterDeployment"},
+	{Group: "hive.openshift.io", Version: "v1", Kind: "MachinePool"},
+	{Group: "hive.openshift.io", Version: "v1", Kind: "ClusterImageSet"},
+	{Group: "cluster.open-cluster-management.io", Version: "v1beta1", Kind: "ClusterCurator"},
+	{Group: "", Version: "v1", Kind: "Secret"},
+	{Group: "", Version: "v1", Kind: "ConfigMap"},
+	{Group: "", Version: "v1", Kind: "Namespace"},
+}
+
+func main() {
+	runID := flag.String("run-id", "", "run ID whose resources should be deleted (required)")
+	kubeconfig := flag.String("kubeconfig", os.Getenv("KUBECONFIG"), "hub kubeconfig")
+	dryRun := flag.Bool("dry-run", false, "only print what would be deleted")
+	timeout := flag.Duration("timeout", 10*time.Minute, "overall timeout")
+	flag.Parse()
+
+	if *runID == "" {
+		fmt.Fprintln(os.Stderr, "--run-id is required")
+		os.Exit(2)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), *timeout)
+	defer cancel()
+
+	restConfig, err := clientcmd.BuildConfigFromFlags("", *kubeconfig)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to load hub kubeconfig: %v\n", err)
+		os.Exit(1)
+	}
+	hub, err := client.New(restConfig, client.Options{})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to build hub client: %v\n", err)
+		os.Exit(1)
+	}
+
+	deleted, failed := 0, 0
+	selector := diagnostics.RunSelector(*runID)
+	for _, gvk := range cleanupKinds {
+		list := &unstructured.UnstructuredList{}
+		list.SetGroupVersionKind(gvk.GroupVersion().WithKind(gvk.Kind + "List"))
+		if err := hub.List(ctx, list, selector); err != nil {
+			// CRD not installed on this hub; nothing of that kind to clean.
+			continue
+		}
+		for i := range list.Items {
+			item := &list.Items[i]
+			name := item.GetName()
+			if namespace := item.GetNamespace(); namespace != "" {
+				name = namespace + "/" + name
+			}
+			if *dryRun {
+				fmt.Printf("would delete %s %s\n", gvk.Kind, name)
+				continue
+			}
+			if err := hub.Delete(ctx, item); err != nil {
+				fmt.Fprintf(os.Stderr, "failed to delete %s %s: %v\n", gvk.Kind, name, err)
+				failed++
+				continue
+			}
+			fmt.Printf("deleted %s %s\n", gvk.Kind, name)
+			deleted++
+		}
+	}
+
+	if *dryRun {
+		return
+	}
+	fmt.Printf("run %s: deleted %d resources, %d failures\n", *runID, deleted, failed)
+	if failed > 0 {
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,97 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package appliers
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// OperationStats aggregates the outcomes of one applier operation kind.
+type OperationStats struct {
+	Count        int64
+	Errors       int64
+	TotalLatency time.Duration
+	MaxLatency   time.Duration
+}
+
+// AverageLatency returns the mean latency of the operation.
+func (s OperationStats) AverageLatency() time.Duration {
+	if s.Count == 0 {
+		return 0
+	}
+	return s.TotalLatency / time.Duration(s.Count)
+}
+
+// Metrics records per-verb applier statistics. A single Metrics value may
+// be shared by several appliers to get a run-wide view; all methods are
+// safe for concurrent use.
+type Metrics struct {
+	mu         sync.Mutex
+	operations map[string]*OperationStats
+}
+
+// NewMetrics returns an empty metrics collector.
+func NewMetrics() *Metrics {
+	return &Metrics{operations: map[string]*OperationStats{}}
+}
+
+// observe records one operation outcome under a "verb kind" key.
+func (m *Metrics) observe(verb, kind string, latency time.Duration, err error) {
+	if m == nil {
+		return
+	}
+	key := verb + " " + kind
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	stats, ok := m.operations[key]
+	if !ok {
+		stats = &OperationStats{}
+		m.operations[key] = stats
+	}
+	stats.Count++
+	if err != nil {
+		stats.Errors++
+	}
+	stats.TotalLatency += latency
+	if latency > stats.MaxLatency {
+		stats.MaxLatency = latency
+	}
+}
+
+// Snapshot returns a copy of the current statistics keyed by "verb kind".
+func (m *Metrics) Snapshot() map[string]OperationStats {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	snapshot := make(map[string]OperationStats, len(m.operations))
+	for key, stats := range m.operations {
+		snapshot[key] = *stats
+	}
+	return snapshot
+}
+
+// Summary renders the statistics as a sorted, human-readable table for
+// suite teardown logs.
+func (m *Metrics) Summary() string {
+	snapshot := m.Snapshot()
+	keys := make([]string, 0, len(snapshot))
+	for key := range snapshot {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var b strings.Builder
+	b.WriteString("applier operations:")
+	for _, key := range keys {
+		stats := snapshot[key]
+		fmt.Fprintf(&b, "\n  %-40s count=%d errors=%d avg=%s max=%s",
+			key, stats.Count, stats.Errors, stats.AverageLatency().Round(time.Millisecond), stats.MaxLatency.Round(time.Millisecond))
+	}
+	if len(keys) == 0 {
+		b.WriteString(" none")
+	}
+	return b.String()
+}
@@ -0,0 +1,103 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package checks
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	addonv1alpha1 "open-cluster-management.io/api/addon/v1alpha1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// AddonHealth is the observed state of one addon on one cluster.
+type AddonHealth struct {
+	Cluster   string
+	Addon     string
+	Available bool
+	Degraded  bool
+	// Message carries the Available condition message for unhealthy rows.
+	Message string
+}
+
+// AddonHealthMatrix collects the addon health of every given cluster.
+// An empty addons slice checks whatever addons are enabled per cluster.
+func AddonHealthMatrix(ctx context.Context, hub client.Client, clusters []string, addons []string) ([]AddonHealth, error) {
+	var matrix []AddonHealth
+	for _, cluster := range clusters {
+		list := &addonv1alpha1.ManagedClusterAddOnList{}
+		if err := hub.List(ctx, list, client.InNamespace(cluster)); err != nil {
+			return nil, fmt.Errorf("failed to list addons on cluster %s: %w", cluster, err)
+		}
+		present := map[string]*addonv1alpha1.ManagedClusterAddOn{}
+		for i := range list.Items {
+			present[list.Items[i].Name] = &list.Items[i]
+		}
+
+		names := addons
+		if len(names) == 0 {
+			names = make([]string, 0, len(present))
+			for name := range present {
+				names = append(names, name)
+			}
+			sort.Strings(names)
+		}
+		for _, name := range names {
+			addon, ok := present[name]
+			if !ok {
+				matrix = append(matrix, AddonHealth{
+					Cluster: cluster,
+					Addon:   name,
+					Message: "addon not enabled",
+				})
+				continue
+			}
+			available := meta.FindStatusCondition(addon.Status.Conditions, addonv1alpha1.ManagedClusterAddOnConditionAvailable)
+			health := AddonHealth{
+				Cluster:   cluster,
+				Addon:     name,
+				Available: available != nil && available.Status == "True",
+				Degraded:  meta.IsStatusConditionTrue(addon.Status.Conditions, addonv1alpha1.ManagedClusterAddOnConditionDegraded),
+			}
+			if !health.Available && available != nil {
+				health.Message = available.Message
+			}
+			matrix = append(matrix, health)
+		}
+	}
+	return matrix, nil
+}
+
+// AssertAddonMatrixHealthy collects the matrix and fails with a readable
+// table of every unhealthy cell, so a suite can assert "these addons are
+// Available on all clusters" in one call.
+func AssertAddonMatrixHealthy(ctx context.Context, hub client.Client, clusters []string, addons []string) error {
+	matrix, err := AddonHealthMatrix(ctx, hub, clusters, addons)
+	if err != nil {
+		return err
+	}
+
+	var unhealthy []string
+	for _, health := range matrix {
+		if health.Available && !health.Degraded {
+			continue
+		}
+		state := "unavailable"
+		if health.Degraded {
+			state = "degraded"
+		}
+		row := fmt.Sprintf("%s/%s %s", health.Cluster, health.Addon, state)
+		if health.Message != "" {
+			row += ": " + health.Message
+		}
+		unhealthy = append(unhealthy, row)
+	}
+	if len(unhealthy) > 0 {
+		return fmt.Errorf("%d of %d addon cells unhealthy:\n  %s",
+			len(unhealthy), len(matrix), strings.Join(unhealthy, "\n  "))
+	}
+	return nil
+}
@@ -0,0 +1,99 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package checks
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// hubKubeconfigSecret holds the klusterlet agent's hub client certificate
+// on the spoke.
+const (
+	agentNamespace      = "open-cluster-management-agent"
+	hubKubeconfigSecret = "hub-kubeconfig-secret"
+)
+
+// KlusterletCertInfo describes the agent's current hub client certificate.
+type KlusterletCertInfo struct {
+	NotBefore time.Time
+	NotAfter  time.Time
+	Subject   string
+}
+
+// GetKlusterletCertInfo reads the klusterlet's hub client certificate from
+// the spoke and returns its validity window.
+func GetKlusterletCertInfo(ctx context.Context, spoke client.Client) (*KlusterletCertInfo, error) {
+	secret := &corev1.Secret{}
+	if err := spoke.Get(ctx, client.ObjectKey{Namespace: agentNamespace, Name: hubKubeconfigSecret}, secret); err != nil {
+		return nil, fmt.Errorf("failed to get hub kubeconfig secret on spoke: %w", err)
+	}
+	raw, ok := secret.Data["tls.crt"]
+	if !ok {
+		return nil, fmt.Errorf("hub kubeconfig secret has no tls.crt")
+	}
+	block, _ := pem.Decode(raw)
+	if block == nil {
+		return nil, fmt.Errorf("hub client certificate is not PEM encoded")
+	}
+	certificate, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse hub client certificate: %w", err)
+	}
+	return &KlusterletCertInfo{
+		NotBefore: certificate.NotBefore,
+		NotAfter:  certificate.NotAfter,
+		Subject:   certificate.Subject.String(),
+	}, nil
+}
+
+// VerifyKlusterletCertValidity asserts the agent's hub client certificate
+// is currently valid and not within minRemaining of expiry. Registration
+// rotates the certificate at ~80% of its lifetime, so a certificate close
+// to expiry means rotation is broken.
+func VerifyKlusterletCertValidity(ctx context.Context, spoke client.Client, minRemaining time.Duration) error {
+	info, err := GetKlusterletCertInfo(ctx, spoke)
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	if now.Before(info.NotBefore) {
+		return fmt.Errorf("hub client certificate is not yet valid (notBefore %s)", info.NotBefore)
+	}
+	if now.After(info.NotAfter) {
+		return fmt.Errorf("hub client certificate expired at %s", info.NotAfter)
+	}
+	if remaining := info.NotAfter.Sub(now); remaining < minRemaining {
+		return fmt.Errorf("hub client certificate expires in %s, expected at least %s — rotation may be broken",
+			remaining.Round(time.Minute), minRemaining)
+	}
+	return nil
+}
+
+// WaitForKlusterletCertRotation waits until the agent presents a hub
+// client certificate issued after the given reference certificate,
+// proving a rotation happened.
+func WaitForKlusterletCertRotation(ctx context.Context, spoke client.Client, previous *KlusterletCertInfo, timeout time.Duration) (*KlusterletCertInfo, error) {
+	deadline := time.Now().Add(timeout)
+	for {
+		info, err := GetKlusterletCertInfo(ctx, spoke)
+		if err == nil && info.NotBefore.After(previous.NotBefore) {
+			return info, nil
+		}
+		if time.Now().After(deadline) {
+			return nil, fmt.Errorf("hub client certificate was not rotated within %s (still issued %s)",
+				timeout, previous.NotBefore)
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(checkPollInterval):
+		}
+	}
+}
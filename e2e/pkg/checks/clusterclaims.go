@@ -0,0 +1,120 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package checks
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	clusterv1 "open-cluster-management.io/api/cluster/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ClaimAssertion is one expectation over a cluster claim.
+type claimAssertion struct {
+	name  string
+	check func(value string, found bool) error
+}
+
+// ClusterClaimExpectation is a small assertion DSL over the cluster claims
+// reported in ManagedCluster status. Build expectations fluently, then run
+// them with Check:
+//
+//	err := checks.ExpectClusterClaims(hub, "cluster1").
+//		Equals("platform.open-cluster-management.io", "AWS").
+//		Matches("version.openshift.io", `^4\.1[5-9]\.`).
+//		Exists("id.k8s.io").
+//		Absent("region.open-cluster-management.io").
+//		Check(ctx)
+type ClusterClaimExpectation struct {
+	hub         client.Client
+	clusterName string
+	assertions  []claimAssertion
+}
+
+// ExpectClusterClaims starts an expectation over a cluster's claims.
+func ExpectClusterClaims(hub client.Client, clusterName string) *ClusterClaimExpectation {
+	return &ClusterClaimExpectation{hub: hub, clusterName: clusterName}
+}
+
+// Equals requires the claim to exist with exactly the given value.
+func (e *ClusterClaimExpectation) Equals(name, value string) *ClusterClaimExpectation {
+	e.assertions = append(e.assertions, claimAssertion{name: name, check: func(got string, found bool) error {
+		if !found {
+			return fmt.Errorf("claim %s is missing", name)
+		}
+		if got != value {
+			return fmt.Errorf("claim %s is %q, expected %q", name, got, value)
+		}
+		return nil
+	}})
+	return e
+}
+
+// Matches requires the claim to exist and match the regular expression.
+func (e *ClusterClaimExpectation) Matches(name, pattern string) *ClusterClaimExpectation {
+	expression, err := regexp.Compile(pattern)
+	e.assertions = append(e.assertions, claimAssertion{name: name, check: func(got string, found bool) error {
+		if err != nil {
+			return fmt.Errorf("claim %s has invalid pattern %q: %w", name, pattern, err)
+		}
+		if !found {
+			return fmt.Errorf("claim %s is missing", name)
+		}
+		if !expression.MatchString(got) {
+			return fmt.Errorf("claim %s is %q, expected to match %q", name, got, pattern)
+		}
+		return nil
+	}})
+	return e
+}
+
+// Exists requires the claim to be present with any non-empty value.
+func (e *ClusterClaimExpectation) Exists(name string) *ClusterClaimExpectation {
+	e.assertions = append(e.assertions, claimAssertion{name: name, check: func(got string, found bool) error {
+		if !found || got == "" {
+			return fmt.Errorf("claim %s is missing or empty", name)
+		}
+		return nil
+	}})
+	return e
+}
+
+// Absent requires the claim not to be reported at all.
+func (e *ClusterClaimExpectation) Absent(name string) *ClusterClaimExpectation {
+	e.assertions = append(e.assertions, claimAssertion{name: name, check: func(got string, found bool) error {
+		if found {
+			return fmt.Errorf("claim %s is present with value %q, expected absent", name, got)
+		}
+		return nil
+	}})
+	return e
+}
+
+// Check evaluates all expectations against the current claims and returns
+// every violation joined into one error.
+func (e *ClusterClaimExpectation) Check(ctx context.Context) error {
+	cluster := &clusterv1.ManagedCluster{}
+	if err := e.hub.Get(ctx, client.ObjectKey{Name: e.clusterName}, cluster); err != nil {
+		return fmt.Errorf("failed to get managed cluster %s: %w", e.clusterName, err)
+	}
+	claims := map[string]string{}
+	for _, claim := range cluster.Status.ClusterClaims {
+		claims[claim.Name] = claim.Value
+	}
+
+	var violations []string
+	for _, assertion := range e.assertions {
+		value, found := claims[assertion.name]
+		if err := assertion.check(value, found); err != nil {
+			violations = append(violations, err.Error())
+		}
+	}
+	if len(violations) > 0 {
+		return fmt.Errorf("cluster %s claim expectations failed:\n  %s",
+			e.clusterName, strings.Join(violations, "\n  "))
+	}
+	return nil
+}
@@ -0,0 +1,86 @@
+// Copyright Contributors to the Open Cluster Management project
+
+// Package checks contains read-only assertions over hub and spoke state.
+// Helpers here never mutate the clusters; they verify that controllers did,
+// and return errors descriptive enough to stand alone in a test report.
+package checks
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	clusterinfov1beta1 "github.com/stolostron/cluster-lifecycle-api/clusterinfo/v1beta1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	checkPollInterval = 10 * time.Second
+	checkWaitTimeout  = 5 * time.Minute
+)
+
+// ClusterInfoExpectation describes what the ManagedClusterInfo of a cluster
+// must report after import. Zero fields are not checked.
+type ClusterInfoExpectation struct {
+	// KubeVendor is the expected vendor, for example OpenShift, EKS, GKE.
+	KubeVendor string
+	// Version is the expected Kubernetes version prefix, for example
+	// "v1.29".
+	Version string
+	// MinNodes is the minimum number of nodes the info must list.
+	MinNodes int
+	// ConsoleURL requires a non-empty console URL when true; OpenShift
+	// clusters report one, most vendor-managed clusters do not.
+	ConsoleURL bool
+	// DistributionVersion is the expected OCP desired version prefix, only
+	// meaningful for OpenShift spokes.
+	DistributionVersion string
+}
+
+// VerifyManagedClusterInfo waits until the ManagedClusterInfo of a cluster
+// meets the expectation, proving the info controller populated the spoke
+// details after import or create.
+func VerifyManagedClusterInfo(ctx context.Context, hub client.Client, clusterName string, expect ClusterInfoExpectation) error {
+	var lastErr error
+	err := wait.PollUntilContextTimeout(ctx, checkPollInterval, checkWaitTimeout, true,
+		func(ctx context.Context) (bool, error) {
+			info := &clusterinfov1beta1.ManagedClusterInfo{}
+			if err := hub.Get(ctx, client.ObjectKey{Namespace: clusterName, Name: clusterName}, info); err != nil {
+				lastErr = fmt.Errorf("failed to get managed cluster info: %w", err)
+				return false, nil
+			}
+			if err := clusterInfoMatches(info, expect); err != nil {
+				lastErr = err
+				return false, nil
+			}
+			return true, nil
+		})
+	if err != nil && lastErr != nil {
+		return fmt.Errorf("managed cluster info of %s never matched: %w: %v", clusterName, err, lastErr)
+	}
+	return err
+}
+
+func clusterInfoMatches(info *clusterinfov1beta1.ManagedClusterInfo, expect ClusterInfoExpectation) error {
+	if expect.KubeVendor != "" && string(info.Status.KubeVendor) != expect.KubeVendor {
+		return fmt.Errorf("kube vendor is %q, expected %q", info.Status.KubeVendor, expect.KubeVendor)
+	}
+	if expect.Version != "" && !strings.HasPrefix(info.Status.Version, expect.Version) {
+		return fmt.Errorf("kubernetes version is %q, expected prefix %q", info.Status.Version, expect.Version)
+	}
+	if len(info.Status.NodeList) < expect.MinNodes {
+		return fmt.Errorf("info lists %d nodes, expected at least %d", len(info.Status.NodeList), expect.MinNodes)
+	}
+	if expect.ConsoleURL && info.Status.ConsoleURL == "" {
+		return fmt.Errorf("info reports no console URL")
+	}
+	if expect.DistributionVersion != "" {
+		ocp := info.Status.DistributionInfo.OCP
+		if !strings.HasPrefix(ocp.DesiredVersion, expect.DistributionVersion) {
+			return fmt.Errorf("OCP desired version is %q, expected prefix %q", ocp.DesiredVersion, expect.DistributionVersion)
+		}
+	}
+	return nil
+}
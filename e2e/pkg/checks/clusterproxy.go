@@ -0,0 +1,60 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package checks
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"k8s.io/client-go/rest"
+)
+
+// clusterProxyPath is the route template through which the cluster-proxy
+// addon exposes spoke API servers on the hub.
+const clusterProxyPath = "/apis/proxy.open-cluster-management.io/v1beta1/namespaces/%s/clusterstatuses/%s/aggregator"
+
+// VerifyClusterProxyConnectivity exercises the cluster-proxy (konnectivity)
+// path: it sends a request to the spoke API server through the hub proxy
+// service and asserts the spoke answers. hubConfig must carry credentials
+// allowed to use the aggregated proxy API.
+func VerifyClusterProxyConnectivity(ctx context.Context, hubConfig *rest.Config, clusterName string) error {
+	transport, err := rest.TransportFor(hubConfig)
+	if err != nil {
+		return fmt.Errorf("failed to build hub transport: %w", err)
+	}
+	httpClient := &http.Client{Transport: transport}
+
+	// /version is unauthenticated on the spoke side of the proxy tunnel
+	// and cheap, which makes it the canonical connectivity probe.
+	url := hubConfig.Host + fmt.Sprintf(clusterProxyPath, clusterName, clusterName) + "/version"
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return fmt.Errorf("failed to build cluster-proxy request for %s: %w", clusterName, err)
+	}
+	response, err := httpClient.Do(request)
+	if err != nil {
+		return fmt.Errorf("cluster-proxy request to %s failed: %w", clusterName, err)
+	}
+	defer response.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(response.Body, 4096))
+	if err != nil {
+		return fmt.Errorf("failed to read cluster-proxy response from %s: %w", clusterName, err)
+	}
+	if response.StatusCode != http.StatusOK {
+		return fmt.Errorf("cluster-proxy to %s answered %d: %s", clusterName, response.StatusCode, string(body))
+	}
+
+	// A healthy tunnel returns the spoke's version document; parse it to
+	// prove the bytes really came from an API server.
+	var version struct {
+		GitVersion string `json:"gitVersion"`
+	}
+	if err := json.Unmarshal(body, &version); err != nil || version.GitVersion == "" {
+		return fmt.Errorf("cluster-proxy to %s returned no version document: %s", clusterName, string(body))
+	}
+	return nil
+}
@@ -0,0 +1,66 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package checks
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	clusterinfov1beta1 "github.com/stolostron/cluster-lifecycle-api/clusterinfo/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ConsoleSmokeProbe fetches the spoke's console URL as reported in
+// ManagedClusterInfo and asserts it serves the console login page. It is a
+// cheap end-to-end signal after import that DNS, the router and the
+// console deployment of the spoke all work.
+func ConsoleSmokeProbe(ctx context.Context, hub client.Client, clusterName string) error {
+	info := &clusterinfov1beta1.ManagedClusterInfo{}
+	if err := hub.Get(ctx, client.ObjectKey{Namespace: clusterName, Name: clusterName}, info); err != nil {
+		return fmt.Errorf("failed to get managed cluster info of %s: %w", clusterName, err)
+	}
+	consoleURL := info.Status.ConsoleURL
+	if consoleURL == "" {
+		return fmt.Errorf("cluster %s reports no console URL; probe does not apply to this vendor", clusterName)
+	}
+	return probeConsole(ctx, clusterName, consoleURL)
+}
+
+func probeConsole(ctx context.Context, clusterName, consoleURL string) error {
+	// Test cluster routers usually serve self-signed certificates; this
+	// probe checks reachability and content, not the trust chain.
+	httpClient := &http.Client{
+		Transport: &http.Transport{
+			TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
+		},
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, consoleURL, nil)
+	if err != nil {
+		return fmt.Errorf("failed to build console request for %s: %w", clusterName, err)
+	}
+	response, err := httpClient.Do(request)
+	if err != nil {
+		return fmt.Errorf("console of cluster %s is unreachable at %s: %w", clusterName, consoleURL, err)
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode >= 500 {
+		return fmt.Errorf("console of cluster %s answered %d at %s", clusterName, response.StatusCode, consoleURL)
+	}
+	body, err := io.ReadAll(io.LimitReader(response.Body, 1<<20))
+	if err != nil {
+		return fmt.Errorf("failed to read console response of cluster %s: %w", clusterName, err)
+	}
+	// The console and its OAuth login page are both HTML documents; any
+	// non-HTML answer means something else squatted on the route.
+	content := strings.ToLower(string(body))
+	if !strings.Contains(content, "<html") {
+		return fmt.Errorf("console of cluster %s did not serve an HTML page at %s", clusterName, consoleURL)
+	}
+	return nil
+}
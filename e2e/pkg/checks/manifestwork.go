@@ -0,0 +1,74 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package checks
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/util/wait"
+	workv1 "open-cluster-management.io/api/work/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// WaitForManifestWorkApplied waits until the ManifestWork reports both
+// Applied and Available, meaning the work agent created the manifests on
+// the spoke and they still exist.
+func WaitForManifestWorkApplied(ctx context.Context, hub client.Client, clusterName, workName string) error {
+	work := &workv1.ManifestWork{}
+	var lastErr error
+	err := wait.PollUntilContextTimeout(ctx, checkPollInterval, checkWaitTimeout, true,
+		func(ctx context.Context) (bool, error) {
+			if err := hub.Get(ctx, client.ObjectKey{Namespace: clusterName, Name: workName}, work); err != nil {
+				return false, err
+			}
+			applied := meta.IsStatusConditionTrue(work.Status.Conditions, workv1.WorkApplied)
+			available := meta.IsStatusConditionTrue(work.Status.Conditions, workv1.WorkAvailable)
+			if !applied || !available {
+				lastErr = fmt.Errorf("applied=%t available=%t", applied, available)
+				return false, nil
+			}
+			return true, nil
+		})
+	if err != nil {
+		return fmt.Errorf("manifest work %s/%s was not applied (%v): %w", clusterName, workName, lastErr, err)
+	}
+	return nil
+}
+
+// VerifyManifestWorkResources asserts that every manifest of the work
+// reports an applied, available per-resource status, and returns a
+// description of every manifest that does not.
+func VerifyManifestWorkResources(ctx context.Context, hub client.Client, clusterName, workName string) error {
+	work := &workv1.ManifestWork{}
+	if err := hub.Get(ctx, client.ObjectKey{Namespace: clusterName, Name: workName}, work); err != nil {
+		return fmt.Errorf("failed to get manifest work %s/%s: %w", clusterName, workName, err)
+	}
+
+	manifestCount := len(work.Spec.Workload.Manifests)
+	statuses := work.Status.ResourceStatus.Manifests
+	if len(statuses) < manifestCount {
+		return fmt.Errorf("manifest work %s/%s reports status for %d of %d manifests",
+			clusterName, workName, len(statuses), manifestCount)
+	}
+
+	var failures []string
+	for _, manifest := range statuses {
+		resource := fmt.Sprintf("%s %s/%s",
+			manifest.ResourceMeta.Kind, manifest.ResourceMeta.Namespace, manifest.ResourceMeta.Name)
+		if !meta.IsStatusConditionTrue(manifest.Conditions, workv1.ManifestApplied) {
+			failures = append(failures, resource+" not applied")
+			continue
+		}
+		if !meta.IsStatusConditionTrue(manifest.Conditions, workv1.ManifestAvailable) {
+			failures = append(failures, resource+" applied but not available")
+		}
+	}
+	if len(failures) > 0 {
+		return fmt.Errorf("manifest work %s/%s has unhealthy manifests:\n  %s",
+			clusterName, workName, strings.Join(failures, "\n  "))
+	}
+	return nil
+}
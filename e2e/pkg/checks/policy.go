@@ -0,0 +1,131 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package checks
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/wait"
+	policiesv1 "open-cluster-management.io/governance-policy-propagator/api/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// policyWaitTimeout allows for propagation plus one evaluation interval of
+// the config policy controller on each spoke.
+const policyWaitTimeout = 10 * time.Minute
+
+// PolicyViolation describes one non-compliant detail of a replicated
+// policy on a managed cluster.
+type PolicyViolation struct {
+	ClusterName  string
+	TemplateName string
+	Message      string
+}
+
+func (v PolicyViolation) String() string {
+	return fmt.Sprintf("%s: template %s: %s", v.ClusterName, v.TemplateName, v.Message)
+}
+
+// WaitForPolicyCompliance waits until the root policy reports the wanted
+// compliance state on every one of the given clusters. Passing no clusters
+// waits on the root policy's aggregated state instead.
+func WaitForPolicyCompliance(ctx context.Context, hub client.Client, namespace, name string,
+	want policiesv1.ComplianceState, clusterNames ...string) error {
+	policy := &policiesv1.Policy{}
+	var lastState string
+	err := wait.PollUntilContextTimeout(ctx, checkPollInterval, policyWaitTimeout, true,
+		func(ctx context.Context) (bool, error) {
+			if err := hub.Get(ctx, client.ObjectKey{Namespace: namespace, Name: name}, policy); err != nil {
+				return false, err
+			}
+			if len(clusterNames) == 0 {
+				lastState = string(policy.Status.ComplianceState)
+				return policy.Status.ComplianceState == want, nil
+			}
+			states := perClusterCompliance(policy)
+			var pending []string
+			for _, clusterName := range clusterNames {
+				if states[clusterName] != want {
+					pending = append(pending, fmt.Sprintf("%s=%s", clusterName, orUnreported(states[clusterName])))
+				}
+			}
+			lastState = strings.Join(pending, ", ")
+			return len(pending) == 0, nil
+		})
+	if err != nil {
+		return fmt.Errorf("policy %s/%s did not reach %s (last: %s): %w", namespace, name, want, lastState, err)
+	}
+	return nil
+}
+
+func perClusterCompliance(policy *policiesv1.Policy) map[string]policiesv1.ComplianceState {
+	states := map[string]policiesv1.ComplianceState{}
+	for _, status := range policy.Status.Status {
+		states[status.ClusterName] = status.ComplianceState
+	}
+	return states
+}
+
+func orUnreported(state policiesv1.ComplianceState) string {
+	if state == "" {
+		return "unreported"
+	}
+	return string(state)
+}
+
+// GetPolicyViolations reads the replicated policies in each cluster
+// namespace and extracts the per-template violation messages, the detail
+// the root policy's aggregated status does not carry.
+func GetPolicyViolations(ctx context.Context, hub client.Client, namespace, name string,
+	clusterNames ...string) ([]PolicyViolation, error) {
+	var violations []PolicyViolation
+	for _, clusterName := range clusterNames {
+		// Replicated policies are named <root namespace>.<root name> in
+		// the cluster namespace.
+		replicated := &policiesv1.Policy{}
+		key := client.ObjectKey{Namespace: clusterName, Name: namespace + "." + name}
+		if err := hub.Get(ctx, key, replicated); err != nil {
+			return nil, fmt.Errorf("failed to get replicated policy %s/%s: %w", key.Namespace, key.Name, err)
+		}
+		for _, detail := range replicated.Status.Details {
+			if detail.ComplianceState == policiesv1.Compliant {
+				continue
+			}
+			message := "no history recorded"
+			if len(detail.History) > 0 {
+				message = detail.History[0].Message
+			}
+			templateName := ""
+			if detail.TemplateMeta.Name != "" {
+				templateName = detail.TemplateMeta.Name
+			}
+			violations = append(violations, PolicyViolation{
+				ClusterName:  clusterName,
+				TemplateName: templateName,
+				Message:      message,
+			})
+		}
+	}
+	return violations, nil
+}
+
+// AssertPolicyCompliant waits for compliance and, when the policy ends up
+// non-compliant instead, fails with the per-cluster violation details.
+func AssertPolicyCompliant(ctx context.Context, hub client.Client, namespace, name string, clusterNames ...string) error {
+	waitErr := WaitForPolicyCompliance(ctx, hub, namespace, name, policiesv1.Compliant, clusterNames...)
+	if waitErr == nil {
+		return nil
+	}
+	violations, err := GetPolicyViolations(ctx, hub, namespace, name, clusterNames...)
+	if err != nil || len(violations) == 0 {
+		return waitErr
+	}
+	lines := make([]string, 0, len(violations))
+	for _, violation := range violations {
+		lines = append(lines, violation.String())
+	}
+	return fmt.Errorf("%w\nviolations:\n  %s", waitErr, strings.Join(lines, "\n  "))
+}
@@ -0,0 +1,103 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package checks
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// hubNamespaces are the namespaces whose workloads must be healthy before a
+// suite run makes sense.
+var hubNamespaces = []string{
+	"open-cluster-management",
+	"open-cluster-management-hub",
+	"multicluster-engine",
+	"hive",
+}
+
+// PreflightResult is the outcome of one preflight probe.
+type PreflightResult struct {
+	Name    string
+	Healthy bool
+	Detail  string
+}
+
+// HubPreflight verifies the hub is in a state worth running suites against:
+// API reachability, hub operator deployments fully available, and no hub
+// pods crash-looping. It returns all probe results so the caller can log
+// the healthy ones too.
+func HubPreflight(ctx context.Context, hub client.Client) ([]PreflightResult, error) {
+	var results []PreflightResult
+
+	// API reachability: a namespace list exercises auth and connectivity.
+	namespaces := &corev1.NamespaceList{}
+	if err := hub.List(ctx, namespaces, client.Limit(1)); err != nil {
+		results = append(results, PreflightResult{Name: "hub API", Detail: err.Error()})
+		return results, fmt.Errorf("hub preflight failed: API unreachable: %w", err)
+	}
+	results = append(results, PreflightResult{Name: "hub API", Healthy: true})
+
+	for _, namespace := range hubNamespaces {
+		result := checkNamespaceWorkloads(ctx, hub, namespace)
+		results = append(results, result)
+	}
+
+	var failures []string
+	for _, result := range results {
+		if !result.Healthy {
+			failures = append(failures, fmt.Sprintf("%s: %s", result.Name, result.Detail))
+		}
+	}
+	if len(failures) > 0 {
+		return results, fmt.Errorf("hub preflight failed:\n  %s", strings.Join(failures, "\n  "))
+	}
+	return results, nil
+}
+
+// checkNamespaceWorkloads verifies every deployment in the namespace is
+// fully available and no pod is crash-looping. A missing namespace is
+// healthy: not every hub installs every component.
+func checkNamespaceWorkloads(ctx context.Context, hub client.Client, namespace string) PreflightResult {
+	result := PreflightResult{Name: "namespace " + namespace}
+
+	deployments := &appsv1.DeploymentList{}
+	if err := hub.List(ctx, deployments, client.InNamespace(namespace)); err != nil {
+		result.Detail = fmt.Sprintf("failed to list deployments: %v", err)
+		return result
+	}
+
+	var problems []string
+	for _, deployment := range deployments.Items {
+		if deployment.Status.AvailableReplicas < *deployment.Spec.Replicas {
+			problems = append(problems, fmt.Sprintf("deployment %s has %d/%d replicas available",
+				deployment.Name, deployment.Status.AvailableReplicas, *deployment.Spec.Replicas))
+		}
+	}
+
+	pods := &corev1.PodList{}
+	if err := hub.List(ctx, pods, client.InNamespace(namespace)); err != nil {
+		result.Detail = fmt.Sprintf("failed to list pods: %v", err)
+		return result
+	}
+	for _, pod := range pods.Items {
+		for _, container := range pod.Status.ContainerStatuses {
+			if container.State.Waiting != nil && container.State.Waiting.Reason == "CrashLoopBackOff" {
+				problems = append(problems, fmt.Sprintf("pod %s container %s is crash-looping (%d restarts)",
+					pod.Name, container.Name, container.RestartCount))
+			}
+		}
+	}
+
+	if len(problems) > 0 {
+		result.Detail = strings.Join(problems, "; ")
+		return result
+	}
+	result.Healthy = true
+	return result
+}
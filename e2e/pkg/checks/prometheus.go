@@ -0,0 +1,118 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package checks
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// PrometheusClient queries the hub's monitoring stack, typically through
+// the thanos-querier route of openshift-monitoring.
+type PrometheusClient struct {
+	// BaseURL is the querier URL, for example
+	// "https://thanos-querier-openshift-monitoring.apps.<hub>".
+	BaseURL string
+	// Token is a bearer token allowed to query metrics.
+	Token string
+	// InsecureSkipTLSVerify accepts self-signed router certificates, the
+	// common case for test hubs.
+	InsecureSkipTLSVerify bool
+}
+
+func (p *PrometheusClient) httpClient() *http.Client {
+	transport := &http.Transport{}
+	if p.InsecureSkipTLSVerify {
+		transport.TLSClientConfig = &tls.Config{InsecureSkipVerify: true}
+	}
+	return &http.Client{Transport: transport}
+}
+
+// Query runs an instant PromQL query and returns the value of the first
+// sample of the result vector.
+func (p *PrometheusClient) Query(ctx context.Context, promql string) (float64, error) {
+	endpoint := fmt.Sprintf("%s/api/v1/query?query=%s", p.BaseURL, url.QueryEscape(promql))
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to build prometheus query: %w", err)
+	}
+	request.Header.Set("Authorization", "Bearer "+p.Token)
+
+	response, err := p.httpClient().Do(request)
+	if err != nil {
+		return 0, fmt.Errorf("prometheus query failed: %w", err)
+	}
+	defer response.Body.Close()
+	body, err := io.ReadAll(io.LimitReader(response.Body, 1<<20))
+	if err != nil {
+		return 0, fmt.Errorf("failed to read prometheus response: %w", err)
+	}
+	if response.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("prometheus answered %d: %s", response.StatusCode, string(body))
+	}
+
+	var parsed struct {
+		Status string `json:"status"`
+		Data   struct {
+			Result []struct {
+				Value [2]interface{} `json:"value"`
+			} `json:"result"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(body, &parsed); err != nil {
+		return 0, fmt.Errorf("failed to parse prometheus response: %w", err)
+	}
+	if parsed.Status != "success" {
+		return 0, fmt.Errorf("prometheus query was not successful: %s", string(body))
+	}
+	if len(parsed.Data.Result) == 0 {
+		return 0, fmt.Errorf("prometheus query %q returned no samples", promql)
+	}
+	raw, ok := parsed.Data.Result[0].Value[1].(string)
+	if !ok {
+		return 0, fmt.Errorf("prometheus sample has unexpected value type")
+	}
+	value, err := strconv.ParseFloat(raw, 64)
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse prometheus sample %q: %w", raw, err)
+	}
+	return value, nil
+}
+
+// AssertMetricAbove asserts the query's first sample is strictly above the
+// threshold.
+func (p *PrometheusClient) AssertMetricAbove(ctx context.Context, promql string, threshold float64) error {
+	value, err := p.Query(ctx, promql)
+	if err != nil {
+		return err
+	}
+	if value <= threshold {
+		return fmt.Errorf("metric %q is %g, expected above %g", promql, value, threshold)
+	}
+	return nil
+}
+
+// AssertMetricEquals asserts the query's first sample equals want.
+func (p *PrometheusClient) AssertMetricEquals(ctx context.Context, promql string, want float64) error {
+	value, err := p.Query(ctx, promql)
+	if err != nil {
+		return err
+	}
+	if value != want {
+		return fmt.Errorf("metric %q is %g, expected %g", promql, value, want)
+	}
+	return nil
+}
+
+// AssertManagedClusterCount asserts the hub's acm_managed_cluster_count
+// metric agrees with the expected number of clusters, the canonical check
+// that hub metrics track lifecycle operations.
+func (p *PrometheusClient) AssertManagedClusterCount(ctx context.Context, want int) error {
+	return p.AssertMetricEquals(ctx, "acm_managed_cluster_count", float64(want))
+}
@@ -0,0 +1,130 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package clusters
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	clusterv1 "open-cluster-management.io/api/cluster/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// CreatePendingCluster registers a ManagedCluster with
+// hubAcceptsClient=false, leaving the registration pending hub acceptance.
+// Combined with an auto-import secret this drives the agent to submit its
+// bootstrap CSR, which then waits for acceptance.
+func CreatePendingCluster(ctx context.Context, hub client.Client, opts ImportOptions) (*clusterv1.ManagedCluster, error) {
+	cluster := &clusterv1.ManagedCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        opts.ClusterName,
+			Labels:      opts.Labels,
+			Annotations: opts.Annotations,
+		},
+		Spec: clusterv1.ManagedClusterSpec{
+			HubAcceptsClient: false,
+		},
+	}
+	if err := hub.Create(ctx, cluster); err != nil && !apierrors.IsAlreadyExists(err) {
+		return nil, fmt.Errorf("failed to create pending managed cluster %s: %w", opts.ClusterName, err)
+	}
+	if len(opts.Kubeconfig) > 0 {
+		if err := CreateAutoImportSecret(ctx, hub, opts.ClusterName, opts.Kubeconfig); err != nil {
+			return nil, err
+		}
+	}
+	return cluster, nil
+}
+
+const (
+	// pendingAcceptanceWindow is how long VerifyClusterPendingAcceptance
+	// keeps watching for a wrongful acceptance before declaring the
+	// cluster pending for good.
+	pendingAcceptanceWindow       = 30 * time.Second
+	pendingAcceptancePollInterval = 5 * time.Second
+)
+
+// VerifyClusterPendingAcceptance asserts that the cluster is registered but
+// not accepted: HubAcceptsClient is false and the HubAccepted condition is
+// not true. It polls for a short window to catch a cluster that is wrongly
+// accepted a moment after registration, and succeeds only when the window
+// passes without an acceptance showing up.
+func VerifyClusterPendingAcceptance(ctx context.Context, hub client.Client, clusterName string) error {
+	err := wait.PollUntilContextTimeout(ctx, pendingAcceptancePollInterval, pendingAcceptanceWindow, true,
+		func(ctx context.Context) (bool, error) {
+			cluster := &clusterv1.ManagedCluster{}
+			if err := hub.Get(ctx, client.ObjectKey{Name: clusterName}, cluster); err != nil {
+				return false, fmt.Errorf("failed to get managed cluster %s: %w", clusterName, err)
+			}
+			if cluster.Spec.HubAcceptsClient {
+				return false, fmt.Errorf("cluster %s has hubAcceptsClient=true, expected pending acceptance", clusterName)
+			}
+			if meta.IsStatusConditionTrue(cluster.Status.Conditions, clusterv1.ManagedClusterConditionHubAccepted) {
+				return false, fmt.Errorf("cluster %s reports HubAccepted despite hubAcceptsClient=false", clusterName)
+			}
+			if meta.IsStatusConditionTrue(cluster.Status.Conditions, clusterv1.ManagedClusterConditionJoined) {
+				return false, fmt.Errorf("cluster %s joined without hub acceptance", clusterName)
+			}
+			return false, nil
+		})
+	// Exhausting the window without observing an acceptance is the
+	// success case; a cancelled caller context is still an error.
+	if wait.Interrupted(err) && ctx.Err() == nil {
+		return nil
+	}
+	return err
+}
+
+// AcceptCluster flips hubAcceptsClient to true and waits for the
+// registration to complete: HubAccepted, then Joined and Available.
+func AcceptCluster(ctx context.Context, hub client.Client, clusterName string) error {
+	err := mutateManagedCluster(ctx, hub, clusterName, func(cluster *clusterv1.ManagedCluster) {
+		cluster.Spec.HubAcceptsClient = true
+	})
+	if err != nil {
+		return err
+	}
+
+	cluster := &clusterv1.ManagedCluster{}
+	err = wait.PollUntilContextTimeout(ctx, autoImportPollInterval, autoImportWaitTimeout, true,
+		func(ctx context.Context) (bool, error) {
+			if err := hub.Get(ctx, client.ObjectKey{Name: clusterName}, cluster); err != nil {
+				return false, err
+			}
+			return meta.IsStatusConditionTrue(cluster.Status.Conditions, clusterv1.ManagedClusterConditionHubAccepted), nil
+		})
+	if err != nil {
+		return fmt.Errorf("cluster %s was not hub-accepted: %w", clusterName, err)
+	}
+	return WaitForClusterJoined(ctx, hub, clusterName)
+}
+
+// RevokeClusterAcceptance flips hubAcceptsClient back to false, which tears
+// down the agent's hub access, and waits for the HubAccepted condition to
+// drop.
+func RevokeClusterAcceptance(ctx context.Context, hub client.Client, clusterName string) error {
+	err := mutateManagedCluster(ctx, hub, clusterName, func(cluster *clusterv1.ManagedCluster) {
+		cluster.Spec.HubAcceptsClient = false
+	})
+	if err != nil {
+		return err
+	}
+
+	cluster := &clusterv1.ManagedCluster{}
+	err = wait.PollUntilContextTimeout(ctx, autoImportPollInterval, autoImportWaitTimeout, true,
+		func(ctx context.Context) (bool, error) {
+			if err := hub.Get(ctx, client.ObjectKey{Name: clusterName}, cluster); err != nil {
+				return false, err
+			}
+			return !meta.IsStatusConditionTrue(cluster.Status.Conditions, clusterv1.ManagedClusterConditionHubAccepted), nil
+		})
+	if err != nil {
+		return fmt.Errorf("acceptance of cluster %s was not revoked: %w", clusterName, err)
+	}
+	return nil
+}
@@ -0,0 +1,109 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package clusters
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	addonv1alpha1 "open-cluster-management.io/api/addon/v1alpha1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	addonPollInterval = 10 * time.Second
+	addonWaitTimeout  = 10 * time.Minute
+
+	// defaultAddonInstallNamespace is where klusterlet addon agents run on
+	// the spoke unless the addon specifies otherwise.
+	defaultAddonInstallNamespace = "open-cluster-management-agent-addon"
+)
+
+// EnableAddon creates (or re-creates) the ManagedClusterAddOn for the named
+// addon on the target cluster and waits for its Available condition.
+// Well-known addon names include work-manager, application-manager,
+// cert-policy-controller and governance-policy-framework.
+func EnableAddon(ctx context.Context, hub client.Client, clusterName, addonName string) (*addonv1alpha1.ManagedClusterAddOn, error) {
+	addon := &addonv1alpha1.ManagedClusterAddOn{
+		ObjectMeta: metav1.ObjectMeta{Name: addonName, Namespace: clusterName},
+		Spec: addonv1alpha1.ManagedClusterAddOnSpec{
+			InstallNamespace: defaultAddonInstallNamespace,
+		},
+	}
+	if err := hub.Create(ctx, addon); err != nil && !apierrors.IsAlreadyExists(err) {
+		return nil, fmt.Errorf("failed to enable addon %s on cluster %s: %w", addonName, clusterName, err)
+	}
+
+	if err := WaitForAddonAvailable(ctx, hub, clusterName, addonName); err != nil {
+		return nil, err
+	}
+	if err := hub.Get(ctx, client.ObjectKey{Namespace: clusterName, Name: addonName}, addon); err != nil {
+		return nil, fmt.Errorf("failed to get addon %s on cluster %s: %w", addonName, clusterName, err)
+	}
+	return addon, nil
+}
+
+// DisableAddon deletes the ManagedClusterAddOn and waits until the object is
+// fully removed, which requires the agent on the spoke to finish cleanup.
+func DisableAddon(ctx context.Context, hub client.Client, clusterName, addonName string) error {
+	addon := &addonv1alpha1.ManagedClusterAddOn{
+		ObjectMeta: metav1.ObjectMeta{Name: addonName, Namespace: clusterName},
+	}
+	if err := hub.Delete(ctx, addon); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to disable addon %s on cluster %s: %w", addonName, clusterName, err)
+	}
+
+	err := wait.PollUntilContextTimeout(ctx, addonPollInterval, addonWaitTimeout, true,
+		func(ctx context.Context) (bool, error) {
+			err := hub.Get(ctx, client.ObjectKey{Namespace: clusterName, Name: addonName}, addon)
+			if apierrors.IsNotFound(err) {
+				return true, nil
+			}
+			return false, err
+		})
+	if err != nil {
+		return fmt.Errorf("addon %s on cluster %s was not removed: %w", addonName, clusterName, err)
+	}
+	return nil
+}
+
+// WaitForAddonAvailable waits until the addon reports Available=True.
+func WaitForAddonAvailable(ctx context.Context, hub client.Client, clusterName, addonName string) error {
+	addon := &addonv1alpha1.ManagedClusterAddOn{}
+	err := wait.PollUntilContextTimeout(ctx, addonPollInterval, addonWaitTimeout, true,
+		func(ctx context.Context) (bool, error) {
+			if err := hub.Get(ctx, client.ObjectKey{Namespace: clusterName, Name: addonName}, addon); err != nil {
+				if apierrors.IsNotFound(err) {
+					return false, nil
+				}
+				return false, err
+			}
+			return meta.IsStatusConditionTrue(addon.Status.Conditions, addonv1alpha1.ManagedClusterAddOnConditionAvailable), nil
+		})
+	if err != nil {
+		return fmt.Errorf("addon %s on cluster %s did not become available: %w", addonName, clusterName, err)
+	}
+	return nil
+}
+
+// ListEnabledAddons returns the names of all addons currently enabled on the
+// target cluster, available or not.
+func ListEnabledAddons(ctx context.Context, hub client.Client, clusterName string) ([]string, error) {
+	addons := &addonv1alpha1.ManagedClusterAddOnList{}
+	if err := hub.List(ctx, addons, client.InNamespace(clusterName)); err != nil {
+		return nil, fmt.Errorf("failed to list addons on cluster %s: %w", clusterName, err)
+	}
+	names := make([]string, 0, len(addons.Items))
+	for _, addon := range addons.Items {
+		names = append(names, addon.Name)
+	}
+	return names, nil
+}
@@ -0,0 +1,115 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package clusters
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	clusterv1 "open-cluster-management.io/api/cluster/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// autoImportSecretName is the well-known secret the import controller
+	// consumes from the cluster namespace to auto-import a cluster.
+	autoImportSecretName = "auto-import-secret"
+
+	// importSucceededCondition is reported on the ManagedCluster by the
+	// import controller.
+	importSucceededCondition = "ManagedClusterImportSucceeded"
+
+	autoImportPollInterval = 15 * time.Second
+	autoImportWaitTimeout  = 10 * time.Minute
+)
+
+// CreateAutoImportSecret writes the auto-import secret for a cluster from a
+// raw kubeconfig, replacing any existing one.
+func CreateAutoImportSecret(ctx context.Context, hub client.Client, clusterName string, kubeconfig []byte) error {
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      autoImportSecretName,
+			Namespace: clusterName,
+		},
+		StringData: map[string]string{
+			"autoImportRetry": "5",
+		},
+		Data: map[string][]byte{
+			"kubeconfig": kubeconfig,
+		},
+	}
+	if err := hub.Create(ctx, secret); err != nil {
+		if !apierrors.IsAlreadyExists(err) {
+			return fmt.Errorf("failed to create auto-import secret for cluster %s: %w", clusterName, err)
+		}
+		if err := hub.Delete(ctx, secret); err != nil && !apierrors.IsNotFound(err) {
+			return fmt.Errorf("failed to replace auto-import secret for cluster %s: %w", clusterName, err)
+		}
+		secret.ResourceVersion = ""
+		if err := hub.Create(ctx, secret); err != nil {
+			return fmt.Errorf("failed to recreate auto-import secret for cluster %s: %w", clusterName, err)
+		}
+	}
+	return nil
+}
+
+// isTokenFailure reports whether an import failure message points at
+// expired or invalid credentials rather than a cluster-side problem.
+func isTokenFailure(message string) bool {
+	for _, marker := range []string{"Unauthorized", "token is expired", "invalid bearer token", "credentials"} {
+		if strings.Contains(message, marker) {
+			return true
+		}
+	}
+	return false
+}
+
+// WaitForAutoImport waits until the import controller reports the import
+// succeeded. When the failure is credential-related (expired or revoked
+// token), the auto-import secret is rebuilt from sourceKubeconfig — which
+// must carry a fresh credential — and the import is retried. Other failures
+// are returned immediately so product bugs are not masked by retries.
+func WaitForAutoImport(ctx context.Context, hub client.Client, clusterName string, sourceKubeconfig func() ([]byte, error)) error {
+	refreshed := false
+	cluster := &clusterv1.ManagedCluster{}
+
+	return wait.PollUntilContextTimeout(ctx, autoImportPollInterval, autoImportWaitTimeout, true,
+		func(ctx context.Context) (bool, error) {
+			if err := hub.Get(ctx, client.ObjectKey{Name: clusterName}, cluster); err != nil {
+				return false, err
+			}
+			condition := meta.FindStatusCondition(cluster.Status.Conditions, importSucceededCondition)
+			if condition == nil {
+				return false, nil
+			}
+			switch condition.Status {
+			case metav1.ConditionTrue:
+				return true, nil
+			case metav1.ConditionFalse:
+				if !isTokenFailure(condition.Message) {
+					return false, fmt.Errorf("auto-import of cluster %s failed: %s", clusterName, condition.Message)
+				}
+				if refreshed {
+					// The refreshed credential also failed; give the
+					// controller the remaining retries before timing out.
+					return false, nil
+				}
+				kubeconfig, err := sourceKubeconfig()
+				if err != nil {
+					return false, fmt.Errorf("failed to refresh import credential for cluster %s: %w", clusterName, err)
+				}
+				if err := CreateAutoImportSecret(ctx, hub, clusterName, kubeconfig); err != nil {
+					return false, err
+				}
+				refreshed = true
+			}
+			return false, nil
+		})
+}
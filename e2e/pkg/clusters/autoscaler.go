@@ -0,0 +1,97 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package clusters
+
+import (
+	"context"
+	"fmt"
+
+	autoscalingv1 "github.com/openshift/cluster-autoscaler-operator/pkg/apis/autoscaling/v1"
+	autoscalingv1beta1 "github.com/openshift/cluster-autoscaler-operator/pkg/apis/autoscaling/v1beta1"
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// EnableMachinePoolAutoscaling switches a MachinePool from fixed replicas
+// to autoscaling between min and max, then verifies the spoke actually
+// received matching MachineAutoscaler resources — not just that the
+// hub-side spec was accepted.
+func EnableMachinePoolAutoscaling(ctx context.Context, hub, spoke client.Client, clusterName, poolName string, min, max int64) error {
+	if min < 0 || max < min {
+		return fmt.Errorf("invalid autoscaling bounds [%d, %d] for pool %s", min, max, poolName)
+	}
+	pool := &hivev1.MachinePool{}
+	key := client.ObjectKey{Namespace: clusterName, Name: poolObjectName(clusterName, poolName)}
+	if err := hub.Get(ctx, key, pool); err != nil {
+		return fmt.Errorf("failed to get machine pool %s: %w", key, err)
+	}
+	pool.Spec.Replicas = nil
+	pool.Spec.Autoscaling = &hivev1.MachinePoolAutoscaling{
+		MinReplicas: int32(min),
+		MaxReplicas: int32(max),
+	}
+	if err := hub.Update(ctx, pool); err != nil {
+		return fmt.Errorf("failed to enable autoscaling on machine pool %s: %w", key, err)
+	}
+
+	return VerifySpokeAutoscaling(ctx, spoke, poolName, min, max)
+}
+
+// VerifySpokeAutoscaling asserts that the spoke's MachineAutoscaler
+// resources for the pool exist and their min/max sums match the requested
+// bounds. Hive distributes the pool bounds over the per-zone MachineSets,
+// so individual autoscalers carry a share while the totals must match.
+func VerifySpokeAutoscaling(ctx context.Context, spoke client.Client, poolName string, min, max int64) error {
+	var lastErr error
+	err := wait.PollUntilContextTimeout(ctx, machinePoolPollInterval, machinePoolWaitTimeout, true,
+		func(ctx context.Context) (bool, error) {
+			sets, err := listPoolMachineSets(ctx, spoke, poolName)
+			if err != nil {
+				return false, err
+			}
+			setNames := map[string]bool{}
+			for _, set := range sets.Items {
+				setNames[set.Name] = true
+			}
+
+			autoscalers := &autoscalingv1beta1.MachineAutoscalerList{}
+			if err := spoke.List(ctx, autoscalers, client.InNamespace("openshift-machine-api")); err != nil {
+				return false, fmt.Errorf("failed to list machine autoscalers: %w", err)
+			}
+			var minTotal, maxTotal int64
+			matched := 0
+			for _, autoscaler := range autoscalers.Items {
+				if !setNames[autoscaler.Spec.ScaleTargetRef.Name] {
+					continue
+				}
+				matched++
+				minTotal += int64(autoscaler.Spec.MinReplicas)
+				maxTotal += int64(autoscaler.Spec.MaxReplicas)
+			}
+			if matched != len(sets.Items) {
+				lastErr = fmt.Errorf("pool %s has %d machine sets but %d machine autoscalers", poolName, len(sets.Items), matched)
+				return false, nil
+			}
+			if minTotal != min || maxTotal != max {
+				lastErr = fmt.Errorf("pool %s autoscalers cover [%d, %d], expected [%d, %d]",
+					poolName, minTotal, maxTotal, min, max)
+				return false, nil
+			}
+			return true, nil
+		})
+	if err != nil && lastErr != nil {
+		return fmt.Errorf("%w: %v", err, lastErr)
+	}
+	return err
+}
+
+// VerifyClusterAutoscalerExists asserts the spoke has a default
+// ClusterAutoscaler, which must exist for MachineAutoscalers to act.
+func VerifyClusterAutoscalerExists(ctx context.Context, spoke client.Client) error {
+	autoscaler := &autoscalingv1.ClusterAutoscaler{}
+	if err := spoke.Get(ctx, client.ObjectKey{Name: "default"}, autoscaler); err != nil {
+		return fmt.Errorf("spoke has no default cluster autoscaler: %w", err)
+	}
+	return nil
+}
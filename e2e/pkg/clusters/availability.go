@@ -0,0 +1,138 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package clusters
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	coordinationv1 "k8s.io/api/coordination/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/utils/ptr"
+	clusterv1 "open-cluster-management.io/api/cluster/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// registrationControllerNamespace hosts the hub-side registration
+// controller whose logs explain stuck registrations.
+const registrationControllerNamespace = "open-cluster-management-hub"
+
+// AvailabilityDiagnostics supplies the extra clients used to enrich a
+// failed availability wait. Nil fields skip the respective source.
+type AvailabilityDiagnostics struct {
+	// Spoke reads klusterlet agent pod status from the managed cluster.
+	Spoke client.Client
+	// HubClientset reads registration controller logs from the hub.
+	HubClientset kubernetes.Interface
+}
+
+// WaitForClusterAvailable waits until the ManagedCluster reports Available.
+// On timeout the returned error aggregates the cluster conditions, the
+// registration lease, the klusterlet agent pod status from the spoke and
+// the tail of the hub registration controller logs, so the failure can be
+// triaged without access to the live environment.
+func WaitForClusterAvailable(ctx context.Context, hub client.Client, clusterName string, timeout time.Duration, diag *AvailabilityDiagnostics) error {
+	cluster := &clusterv1.ManagedCluster{}
+	err := wait.PollUntilContextTimeout(ctx, autoImportPollInterval, timeout, true,
+		func(ctx context.Context) (bool, error) {
+			if err := hub.Get(ctx, client.ObjectKey{Name: clusterName}, cluster); err != nil {
+				return false, err
+			}
+			return meta.IsStatusConditionTrue(cluster.Status.Conditions, clusterv1.ManagedClusterConditionAvailable), nil
+		})
+	if err == nil {
+		return nil
+	}
+
+	sections := []string{
+		formatClusterConditions(cluster),
+		formatClusterLease(ctx, hub, clusterName),
+	}
+	if diag != nil && diag.Spoke != nil {
+		sections = append(sections, formatAgentPods(ctx, diag.Spoke))
+	}
+	if diag != nil && diag.HubClientset != nil {
+		sections = append(sections, formatRegistrationLogs(ctx, diag.HubClientset))
+	}
+	return fmt.Errorf("cluster %s did not become available within %s: %w\n%s",
+		clusterName, timeout, err, strings.Join(sections, "\n"))
+}
+
+// formatClusterLease reports the registration lease freshness; a stale
+// lease means the agent stopped renewing, a missing one that it never
+// registered.
+func formatClusterLease(ctx context.Context, hub client.Client, clusterName string) string {
+	lease := &coordinationv1.Lease{}
+	err := hub.Get(ctx, client.ObjectKey{Namespace: clusterName, Name: "managed-cluster-lease"}, lease)
+	if err != nil {
+		return fmt.Sprintf("lease: failed to get managed-cluster-lease: %v", err)
+	}
+	if lease.Spec.RenewTime == nil {
+		return "lease: never renewed"
+	}
+	return fmt.Sprintf("lease: last renewed %s ago", time.Since(lease.Spec.RenewTime.Time).Round(time.Second))
+}
+
+// formatAgentPods summarizes the klusterlet agent pods on the spoke.
+func formatAgentPods(ctx context.Context, spoke client.Client) string {
+	pods := &corev1.PodList{}
+	if err := spoke.List(ctx, pods, client.InNamespace(klusterletNamespace)); err != nil {
+		return fmt.Sprintf("agent pods: failed to list: %v", err)
+	}
+	if len(pods.Items) == 0 {
+		return fmt.Sprintf("agent pods: none in %s", klusterletNamespace)
+	}
+	var b strings.Builder
+	b.WriteString("agent pods:")
+	for _, pod := range pods.Items {
+		fmt.Fprintf(&b, "\n  %s phase=%s", pod.Name, pod.Status.Phase)
+		for _, container := range pod.Status.ContainerStatuses {
+			if container.Ready {
+				continue
+			}
+			state := "not ready"
+			if container.State.Waiting != nil {
+				state = fmt.Sprintf("waiting (%s)", container.State.Waiting.Reason)
+			} else if container.State.Terminated != nil {
+				state = fmt.Sprintf("terminated (%s)", container.State.Terminated.Reason)
+			}
+			fmt.Fprintf(&b, " container %s %s restarts=%d", container.Name, state, container.RestartCount)
+		}
+	}
+	return b.String()
+}
+
+// formatRegistrationLogs returns the tail of the hub registration
+// controller logs.
+func formatRegistrationLogs(ctx context.Context, hubClientset kubernetes.Interface) string {
+	pods, err := hubClientset.CoreV1().Pods(registrationControllerNamespace).List(ctx, metav1.ListOptions{
+		LabelSelector: "app=clustermanager-registration-controller",
+	})
+	if err != nil || len(pods.Items) == 0 {
+		return fmt.Sprintf("registration controller logs: no pods found (%v)", err)
+	}
+
+	var b strings.Builder
+	b.WriteString("registration controller logs:")
+	for _, pod := range pods.Items {
+		request := hubClientset.CoreV1().Pods(pod.Namespace).GetLogs(pod.Name, &corev1.PodLogOptions{
+			TailLines: ptr.To(int64(50)),
+		})
+		stream, err := request.Stream(ctx)
+		if err != nil {
+			fmt.Fprintf(&b, "\n  %s: failed to stream: %v", pod.Name, err)
+			continue
+		}
+		raw, _ := io.ReadAll(stream)
+		stream.Close()
+		fmt.Fprintf(&b, "\n--- %s ---\n%s", pod.Name, string(raw))
+	}
+	return b.String()
+}
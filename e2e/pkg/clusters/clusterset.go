@@ -0,0 +1,146 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package clusters
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	clusterv1 "open-cluster-management.io/api/cluster/v1"
+	clusterv1beta2 "open-cluster-management.io/api/cluster/v1beta2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// clusterSetLabel assigns a ManagedCluster to a ManagedClusterSet.
+	clusterSetLabel = "cluster.open-cluster-management.io/clusterset"
+
+	clusterSetPollInterval = 5 * time.Second
+	clusterSetWaitTimeout  = 2 * time.Minute
+)
+
+// CreateManagedClusterSet creates a ManagedClusterSet with the default
+// (label-based) selector and waits for its Empty condition to be reported,
+// which indicates the set controller has reconciled it.
+func CreateManagedClusterSet(ctx context.Context, hub client.Client, name string) (*clusterv1beta2.ManagedClusterSet, error) {
+	set := &clusterv1beta2.ManagedClusterSet{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+	}
+	if err := hub.Create(ctx, set); err != nil && !apierrors.IsAlreadyExists(err) {
+		return nil, fmt.Errorf("failed to create managed cluster set %s: %w", name, err)
+	}
+
+	err := wait.PollUntilContextTimeout(ctx, clusterSetPollInterval, clusterSetWaitTimeout, true,
+		func(ctx context.Context) (bool, error) {
+			if err := hub.Get(ctx, client.ObjectKey{Name: name}, set); err != nil {
+				return false, err
+			}
+			return meta.FindStatusCondition(set.Status.Conditions, clusterv1beta2.ManagedClusterSetConditionEmpty) != nil, nil
+		})
+	if err != nil {
+		return nil, fmt.Errorf("managed cluster set %s was not reconciled: %w", name, err)
+	}
+	return set, nil
+}
+
+// DeleteManagedClusterSet removes a ManagedClusterSet. Member clusters keep
+// running; only their set assignment label becomes dangling.
+func DeleteManagedClusterSet(ctx context.Context, hub client.Client, name string) error {
+	set := &clusterv1beta2.ManagedClusterSet{ObjectMeta: metav1.ObjectMeta{Name: name}}
+	if err := hub.Delete(ctx, set); err != nil && !apierrors.IsNotFound(err) {
+		return fmt.Errorf("failed to delete managed cluster set %s: %w", name, err)
+	}
+	return nil
+}
+
+// AddClusterToSet labels a ManagedCluster into a set.
+func AddClusterToSet(ctx context.Context, hub client.Client, clusterName, setName string) error {
+	return setClusterSetLabel(ctx, hub, clusterName, setName)
+}
+
+// RemoveClusterFromSet clears a ManagedCluster's set assignment.
+func RemoveClusterFromSet(ctx context.Context, hub client.Client, clusterName string) error {
+	return setClusterSetLabel(ctx, hub, clusterName, "")
+}
+
+func setClusterSetLabel(ctx context.Context, hub client.Client, clusterName, setName string) error {
+	cluster := &clusterv1.ManagedCluster{}
+	if err := hub.Get(ctx, client.ObjectKey{Name: clusterName}, cluster); err != nil {
+		return fmt.Errorf("failed to get managed cluster %s: %w", clusterName, err)
+	}
+	if setName == "" {
+		delete(cluster.Labels, clusterSetLabel)
+	} else {
+		if cluster.Labels == nil {
+			cluster.Labels = map[string]string{}
+		}
+		cluster.Labels[clusterSetLabel] = setName
+	}
+	if err := hub.Update(ctx, cluster); err != nil {
+		return fmt.Errorf("failed to update set assignment of managed cluster %s: %w", clusterName, err)
+	}
+	return nil
+}
+
+// BindClusterSetToNamespace creates a ManagedClusterSetBinding so workloads
+// in the namespace can target the set, and waits for the binding's Bound
+// condition.
+func BindClusterSetToNamespace(ctx context.Context, hub client.Client, setName, namespace string) (*clusterv1beta2.ManagedClusterSetBinding, error) {
+	binding := &clusterv1beta2.ManagedClusterSetBinding{
+		ObjectMeta: metav1.ObjectMeta{Name: setName, Namespace: namespace},
+		Spec:       clusterv1beta2.ManagedClusterSetBindingSpec{ClusterSet: setName},
+	}
+	if err := hub.Create(ctx, binding); err != nil && !apierrors.IsAlreadyExists(err) {
+		return nil, fmt.Errorf("failed to bind cluster set %s into namespace %s: %w", setName, namespace, err)
+	}
+
+	err := wait.PollUntilContextTimeout(ctx, clusterSetPollInterval, clusterSetWaitTimeout, true,
+		func(ctx context.Context) (bool, error) {
+			if err := hub.Get(ctx, client.ObjectKey{Name: setName, Namespace: namespace}, binding); err != nil {
+				return false, err
+			}
+			return meta.IsStatusConditionTrue(binding.Status.Conditions, clusterv1beta2.ClusterSetBindingBoundType), nil
+		})
+	if err != nil {
+		return nil, fmt.Errorf("cluster set binding %s/%s did not become bound: %w", namespace, setName, err)
+	}
+	return binding, nil
+}
+
+// VerifySetMembership asserts that the set reports exactly the expected
+// member clusters, comparing against both the set label on the clusters and
+// the set's Empty condition.
+func VerifySetMembership(ctx context.Context, hub client.Client, setName string, expected []string) error {
+	clusters := &clusterv1.ManagedClusterList{}
+	if err := hub.List(ctx, clusters, client.MatchingLabels{clusterSetLabel: setName}); err != nil {
+		return fmt.Errorf("failed to list members of cluster set %s: %w", setName, err)
+	}
+
+	members := map[string]bool{}
+	for _, cluster := range clusters.Items {
+		members[cluster.Name] = true
+	}
+	if len(members) != len(expected) {
+		return fmt.Errorf("cluster set %s has %d members, expected %d", setName, len(members), len(expected))
+	}
+	for _, name := range expected {
+		if !members[name] {
+			return fmt.Errorf("cluster %s is not a member of set %s", name, setName)
+		}
+	}
+
+	set := &clusterv1beta2.ManagedClusterSet{}
+	if err := hub.Get(ctx, client.ObjectKey{Name: setName}, set); err != nil {
+		return fmt.Errorf("failed to get managed cluster set %s: %w", setName, err)
+	}
+	empty := meta.IsStatusConditionTrue(set.Status.Conditions, clusterv1beta2.ManagedClusterSetConditionEmpty)
+	if empty && len(expected) > 0 {
+		return fmt.Errorf("cluster set %s reports Empty but %d members are expected", setName, len(expected))
+	}
+	return nil
+}
@@ -0,0 +1,86 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package clusters
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/util/wait"
+	clusterv1 "open-cluster-management.io/api/cluster/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// WaitForManagedClusterCondition waits until the named condition is true on
+// the ManagedCluster, with a caller-chosen timeout. On timeout the error
+// carries the full condition list and the recent events from the cluster
+// namespace, so a failed wait is diagnosable from the test output alone.
+func WaitForManagedClusterCondition(ctx context.Context, hub client.Client, clusterName, conditionType string, timeout time.Duration) error {
+	cluster := &clusterv1.ManagedCluster{}
+	err := wait.PollUntilContextTimeout(ctx, autoImportPollInterval, timeout, true,
+		func(ctx context.Context) (bool, error) {
+			if err := hub.Get(ctx, client.ObjectKey{Name: clusterName}, cluster); err != nil {
+				return false, err
+			}
+			return meta.IsStatusConditionTrue(cluster.Status.Conditions, conditionType), nil
+		})
+	if err == nil {
+		return nil
+	}
+
+	return fmt.Errorf("cluster %s did not reach condition %s within %s: %w\n%s\n%s",
+		clusterName, conditionType, timeout, err,
+		formatClusterConditions(cluster),
+		formatRecentEvents(ctx, hub, clusterName))
+}
+
+// formatClusterConditions renders the condition list of a ManagedCluster
+// for inclusion in timeout errors.
+func formatClusterConditions(cluster *clusterv1.ManagedCluster) string {
+	if len(cluster.Status.Conditions) == 0 {
+		return "conditions: none reported"
+	}
+	var b strings.Builder
+	b.WriteString("conditions:")
+	for _, condition := range cluster.Status.Conditions {
+		fmt.Fprintf(&b, "\n  %s=%s reason=%s: %s",
+			condition.Type, condition.Status, condition.Reason, condition.Message)
+	}
+	return b.String()
+}
+
+// formatRecentEvents renders the most recent events of the cluster
+// namespace. Event listing is best-effort; failures are reported inline
+// rather than masking the original timeout.
+func formatRecentEvents(ctx context.Context, hub client.Client, clusterName string) string {
+	events := &corev1.EventList{}
+	if err := hub.List(ctx, events, client.InNamespace(clusterName)); err != nil {
+		return fmt.Sprintf("events: failed to list: %v", err)
+	}
+	if len(events.Items) == 0 {
+		return "events: none"
+	}
+
+	const maxEvents = 20
+	start := 0
+	if len(events.Items) > maxEvents {
+		start = len(events.Items) - maxEvents
+	}
+	var b strings.Builder
+	b.WriteString("events:")
+	for _, event := range events.Items[start:] {
+		fmt.Fprintf(&b, "\n  %s %s %s/%s: %s",
+			event.Type, event.Reason, event.InvolvedObject.Kind, event.InvolvedObject.Name, event.Message)
+	}
+	return b.String()
+}
+
+// WaitForClusterAvailableFor waits for the Available condition with a
+// caller-chosen timeout, the common case of the generic condition wait.
+func WaitForClusterAvailableFor(ctx context.Context, hub client.Client, clusterName string, timeout time.Duration) error {
+	return WaitForManagedClusterCondition(ctx, hub, clusterName, clusterv1.ManagedClusterConditionAvailable, timeout)
+}
@@ -0,0 +1,236 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package clusters
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	hivev1aws "github.com/openshift/hive/apis/hive/v1/aws"
+	hivev1azure "github.com/openshift/hive/apis/hive/v1/azure"
+	hivev1gcp "github.com/openshift/hive/apis/hive/v1/gcp"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	createClusterPollInterval = 30 * time.Second
+	createClusterWaitTimeout  = 90 * time.Minute
+)
+
+// InstallConfigOverrides carries structured overrides that are merged into
+// the generated install-config before provisioning, so tests do not need a
+// dedicated static template per variation.
+type InstallConfigOverrides struct {
+	WorkerReplicas           *int64
+	WorkerInstanceType       string
+	ControlPlaneInstanceType string
+	ClusterNetworkCIDR       string
+	ServiceNetworkCIDR       string
+	MachineCIDR              string
+	FIPS                     *bool
+}
+
+// CreateClusterOptions describes a Hive-provisioned cluster to create.
+type CreateClusterOptions struct {
+	Name       string
+	Cloud      string
+	Region     string
+	BaseDomain string
+	// ImageSetRef names the ClusterImageSet selecting the OpenShift release.
+	ImageSetRef string
+	// CredentialsSecret is the cloud credential secret in the cluster
+	// namespace.
+	CredentialsSecret string
+	// PullSecret and SSHPrivateKey name the respective secrets in the
+	// cluster namespace.
+	PullSecret    string
+	SSHPrivateKey string
+	Overrides     InstallConfigOverrides
+}
+
+// baseInstallConfig renders the minimal install-config document for a cloud.
+func baseInstallConfig(opts CreateClusterOptions) map[string]interface{} {
+	return map[string]interface{}{
+		"apiVersion": "v1",
+		"metadata":   map[string]interface{}{"name": opts.Name},
+		"baseDomain": opts.BaseDomain,
+		"controlPlane": map[string]interface{}{
+			"name":     "master",
+			"replicas": int64(3),
+			"platform": map[string]interface{}{},
+		},
+		"compute": []interface{}{
+			map[string]interface{}{
+				"name":     "worker",
+				"replicas": int64(3),
+				"platform": map[string]interface{}{},
+			},
+		},
+		"networking": map[string]interface{}{
+			"networkType": "OVNKubernetes",
+		},
+		"platform": map[string]interface{}{
+			opts.Cloud: map[string]interface{}{"region": opts.Region},
+		},
+	}
+}
+
+// applyInstallConfigOverrides merges the structured overrides into an
+// install-config document in place.
+func applyInstallConfigOverrides(config map[string]interface{}, cloud string, overrides InstallConfigOverrides) {
+	compute := config["compute"].([]interface{})[0].(map[string]interface{})
+	controlPlane := config["controlPlane"].(map[string]interface{})
+
+	if overrides.WorkerReplicas != nil {
+		compute["replicas"] = *overrides.WorkerReplicas
+	}
+	if overrides.WorkerInstanceType != "" {
+		compute["platform"] = map[string]interface{}{
+			cloud: map[string]interface{}{"type": overrides.WorkerInstanceType},
+		}
+	}
+	if overrides.ControlPlaneInstanceType != "" {
+		controlPlane["platform"] = map[string]interface{}{
+			cloud: map[string]interface{}{"type": overrides.ControlPlaneInstanceType},
+		}
+	}
+	networking := config["networking"].(map[string]interface{})
+	if overrides.ClusterNetworkCIDR != "" {
+		networking["clusterNetwork"] = []interface{}{
+			map[string]interface{}{"cidr": overrides.ClusterNetworkCIDR, "hostPrefix": int64(23)},
+		}
+	}
+	if overrides.ServiceNetworkCIDR != "" {
+		networking["serviceNetwork"] = []interface{}{overrides.ServiceNetworkCIDR}
+	}
+	if overrides.MachineCIDR != "" {
+		networking["machineNetwork"] = []interface{}{
+			map[string]interface{}{"cidr": overrides.MachineCIDR},
+		}
+	}
+	if overrides.FIPS != nil {
+		config["fips"] = *overrides.FIPS
+	}
+}
+
+// installConfigSecretName returns the name of the install-config secret
+// referenced by the ClusterDeployment.
+func installConfigSecretName(clusterName string) string {
+	return fmt.Sprintf("%s-install-config", clusterName)
+}
+
+// CreateCluster provisions a cluster through Hive: it renders the
+// install-config (with overrides applied), writes the install-config secret
+// and creates the ClusterDeployment, then waits for the install to finish.
+func CreateCluster(ctx context.Context, hub client.Client, opts CreateClusterOptions) (*hivev1.ClusterDeployment, error) {
+	return createClusterWithConfig(ctx, hub, opts, nil)
+}
+
+// createClusterWithConfig is CreateCluster with an optional hook that can
+// mutate the rendered install-config before it is written, for scenarios
+// that need platform settings beyond the structured overrides.
+func createClusterWithConfig(ctx context.Context, hub client.Client, opts CreateClusterOptions, mutate func(map[string]interface{})) (*hivev1.ClusterDeployment, error) {
+	namespace := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: opts.Name}}
+	if err := hub.Create(ctx, namespace); err != nil && !apierrors.IsAlreadyExists(err) {
+		return nil, fmt.Errorf("failed to create namespace for cluster %s: %w", opts.Name, err)
+	}
+
+	config := baseInstallConfig(opts)
+	applyInstallConfigOverrides(config, opts.Cloud, opts.Overrides)
+	if mutate != nil {
+		mutate(config)
+	}
+	raw, err := yaml.Marshal(config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to render install-config for cluster %s: %w", opts.Name, err)
+	}
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      installConfigSecretName(opts.Name),
+			Namespace: opts.Name,
+		},
+		Data: map[string][]byte{"install-config.yaml": raw},
+	}
+	if err := hub.Create(ctx, secret); err != nil && !apierrors.IsAlreadyExists(err) {
+		return nil, fmt.Errorf("failed to create install-config secret for cluster %s: %w", opts.Name, err)
+	}
+
+	deployment, err := newClusterDeployment(opts)
+	if err != nil {
+		return nil, err
+	}
+	if err := hub.Create(ctx, deployment); err != nil {
+		return nil, fmt.Errorf("failed to create cluster deployment %s: %w", opts.Name, err)
+	}
+
+	if err := WaitForClusterDeploymentInstalled(ctx, hub, opts.Name); err != nil {
+		return nil, err
+	}
+	return deployment, nil
+}
+
+// newClusterDeployment builds the ClusterDeployment for the options,
+// including the per-cloud platform block.
+func newClusterDeployment(opts CreateClusterOptions) (*hivev1.ClusterDeployment, error) {
+	deployment := &hivev1.ClusterDeployment{
+		ObjectMeta: metav1.ObjectMeta{Name: opts.Name, Namespace: opts.Name},
+		Spec: hivev1.ClusterDeploymentSpec{
+			ClusterName: opts.Name,
+			BaseDomain:  opts.BaseDomain,
+			Provisioning: &hivev1.Provisioning{
+				InstallConfigSecretRef: &corev1.LocalObjectReference{
+					Name: installConfigSecretName(opts.Name),
+				},
+				ImageSetRef: &hivev1.ClusterImageSetReference{Name: opts.ImageSetRef},
+			},
+			PullSecretRef: &corev1.LocalObjectReference{Name: opts.PullSecret},
+		},
+	}
+	credentials := corev1.LocalObjectReference{Name: opts.CredentialsSecret}
+	switch opts.Cloud {
+	case "aws":
+		deployment.Spec.Platform.AWS = &hivev1aws.Platform{
+			Region:               opts.Region,
+			CredentialsSecretRef: credentials,
+		}
+	case "gcp":
+		deployment.Spec.Platform.GCP = &hivev1gcp.Platform{
+			Region:               opts.Region,
+			CredentialsSecretRef: credentials,
+		}
+	case "azure":
+		deployment.Spec.Platform.Azure = &hivev1azure.Platform{
+			Region:               opts.Region,
+			CredentialsSecretRef: credentials,
+		}
+	default:
+		return nil, fmt.Errorf("unsupported cloud %q for cluster %s", opts.Cloud, opts.Name)
+	}
+	return deployment, nil
+}
+
+// WaitForClusterDeploymentInstalled waits until Hive marks the
+// ClusterDeployment installed, failing fast on a terminal ProvisionStopped
+// condition.
+func WaitForClusterDeploymentInstalled(ctx context.Context, hub client.Client, clusterName string) error {
+	deployment := &hivev1.ClusterDeployment{}
+	return wait.PollUntilContextTimeout(ctx, createClusterPollInterval, createClusterWaitTimeout, true,
+		func(ctx context.Context) (bool, error) {
+			if err := hub.Get(ctx, client.ObjectKey{Namespace: clusterName, Name: clusterName}, deployment); err != nil {
+				return false, err
+			}
+			for _, condition := range deployment.Status.Conditions {
+				if condition.Type == hivev1.ProvisionStoppedCondition && condition.Status == corev1.ConditionTrue {
+					return false, fmt.Errorf("provision of cluster %s stopped: %s", clusterName, condition.Message)
+				}
+			}
+			return deployment.Spec.Installed, nil
+		})
+}
@@ -0,0 +1,94 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package clusters
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	certificatesv1 "k8s.io/api/certificates/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes"
+	clusterv1 "open-cluster-management.io/api/cluster/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// csrClusterNameLabel is stamped by the registration agent on the
+	// bootstrap CSRs it submits for a cluster.
+	csrClusterNameLabel = "open-cluster-management.io/cluster-name"
+
+	csrPollInterval = 10 * time.Second
+	csrWaitTimeout  = 10 * time.Minute
+)
+
+// ApproveClusterCSRs approves all pending certificate signing requests
+// submitted by the registration agent of the named cluster. Hubs with
+// auto-approval enabled never leave CSRs pending, so this is only needed
+// for imports onto hardened hubs.
+//
+// The typed clientset is required because CSR approval is a subresource not
+// exposed through the generic client.
+func ApproveClusterCSRs(ctx context.Context, hubClientset kubernetes.Interface, clusterName string) (int, error) {
+	selector := fmt.Sprintf("%s=%s", csrClusterNameLabel, clusterName)
+	csrs, err := hubClientset.CertificatesV1().CertificateSigningRequests().List(ctx,
+		metav1.ListOptions{LabelSelector: selector})
+	if err != nil {
+		return 0, fmt.Errorf("failed to list CSRs of cluster %s: %w", clusterName, err)
+	}
+
+	approved := 0
+	for i := range csrs.Items {
+		csr := &csrs.Items[i]
+		if csrIsDecided(csr) {
+			continue
+		}
+		csr.Status.Conditions = append(csr.Status.Conditions, certificatesv1.CertificateSigningRequestCondition{
+			Type:    certificatesv1.CertificateApproved,
+			Status:  "True",
+			Reason:  "E2EApproved",
+			Message: "approved by the e2e framework for cluster import",
+		})
+		_, err := hubClientset.CertificatesV1().CertificateSigningRequests().UpdateApproval(ctx,
+			csr.Name, csr, metav1.UpdateOptions{})
+		if err != nil {
+			return approved, fmt.Errorf("failed to approve CSR %s of cluster %s: %w", csr.Name, clusterName, err)
+		}
+		approved++
+	}
+	return approved, nil
+}
+
+func csrIsDecided(csr *certificatesv1.CertificateSigningRequest) bool {
+	for _, condition := range csr.Status.Conditions {
+		if condition.Type == certificatesv1.CertificateApproved || condition.Type == certificatesv1.CertificateDenied {
+			return true
+		}
+	}
+	return false
+}
+
+// WaitForAndApproveClusterCSRs keeps approving the cluster's bootstrap CSRs
+// until the registration completes (the cluster joins) or the timeout
+// expires. Registration can submit more than one CSR — the initial
+// bootstrap and renewals — so a single approval pass is not sufficient.
+func WaitForAndApproveClusterCSRs(ctx context.Context, hub client.Client, hubClientset kubernetes.Interface, clusterName string) error {
+	err := wait.PollUntilContextTimeout(ctx, csrPollInterval, csrWaitTimeout, true,
+		func(ctx context.Context) (bool, error) {
+			if _, err := ApproveClusterCSRs(ctx, hubClientset, clusterName); err != nil {
+				return false, err
+			}
+			cluster := &clusterv1.ManagedCluster{}
+			if err := hub.Get(ctx, client.ObjectKey{Name: clusterName}, cluster); err != nil {
+				return false, err
+			}
+			return meta.IsStatusConditionTrue(cluster.Status.Conditions, clusterv1.ManagedClusterConditionJoined), nil
+		})
+	if err != nil {
+		return fmt.Errorf("cluster %s did not join after CSR approval: %w", clusterName, err)
+	}
+	return nil
+}
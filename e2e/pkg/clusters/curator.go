@@ -0,0 +1,138 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package clusters
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	ansiblejobv1alpha1 "github.com/open-cluster-management/ansiblejob-go-lib/api/v1alpha1"
+	clustercuratorv1 "github.com/stolostron/cluster-curator-controller/pkg/api/v1beta1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	curatorPollInterval = 20 * time.Second
+	curatorWaitTimeout  = 30 * time.Minute
+)
+
+// CuratorHook names an Ansible Tower/AAP job template to run around a
+// curator operation, with optional extra variables passed to the job.
+type CuratorHook struct {
+	TemplateName string
+	ExtraVars    map[string]interface{}
+}
+
+// ConfigureCuratorHooks sets prehook and posthook Ansible jobs for the given
+// curation (install, upgrade or destroy) on the cluster's ClusterCurator.
+// The tower credential secret must already exist in the cluster namespace.
+func ConfigureCuratorHooks(ctx context.Context, hub client.Client, clusterName, curation, towerSecret string, prehooks, posthooks []CuratorHook) error {
+	curator := &clustercuratorv1.ClusterCurator{}
+	if err := hub.Get(ctx, client.ObjectKey{Namespace: clusterName, Name: clusterName}, curator); err != nil {
+		return fmt.Errorf("failed to get cluster curator for %s: %w", clusterName, err)
+	}
+
+	prehook, err := toCuratorHooks(prehooks)
+	if err != nil {
+		return err
+	}
+	posthook, err := toCuratorHooks(posthooks)
+	if err != nil {
+		return err
+	}
+	hooks := clustercuratorv1.Hooks{
+		TowerAuthSecret: towerSecret,
+		Prehook:         prehook,
+		Posthook:        posthook,
+	}
+	switch curation {
+	case "install":
+		curator.Spec.Install = hooks
+	case "upgrade":
+		curator.Spec.Upgrade.TowerAuthSecret = hooks.TowerAuthSecret
+		curator.Spec.Upgrade.Prehook = hooks.Prehook
+		curator.Spec.Upgrade.Posthook = hooks.Posthook
+	case "destroy":
+		curator.Spec.Destroy = hooks
+	default:
+		return fmt.Errorf("unsupported curation %q, expected install, upgrade or destroy", curation)
+	}
+
+	if err := hub.Update(ctx, curator); err != nil {
+		return fmt.Errorf("failed to configure %s hooks on cluster curator %s: %w", curation, clusterName, err)
+	}
+	return nil
+}
+
+func toCuratorHooks(hooks []CuratorHook) ([]clustercuratorv1.Hook, error) {
+	result := make([]clustercuratorv1.Hook, 0, len(hooks))
+	for _, hook := range hooks {
+		converted := clustercuratorv1.Hook{Name: hook.TemplateName}
+		if len(hook.ExtraVars) > 0 {
+			raw, err := json.Marshal(hook.ExtraVars)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal extra vars of hook template %s: %w", hook.TemplateName, err)
+			}
+			converted.ExtraVars = &runtime.RawExtension{Raw: raw}
+		}
+		result = append(result, converted)
+	}
+	return result, nil
+}
+
+// VerifyCuratorHookJobs waits until an AnsibleJob exists in the cluster
+// namespace for every configured hook template and all of them report a
+// successful run.
+func VerifyCuratorHookJobs(ctx context.Context, hub client.Client, clusterName string, hooks []CuratorHook) error {
+	var lastErr error
+	err := wait.PollUntilContextTimeout(ctx, curatorPollInterval, curatorWaitTimeout, true,
+		func(ctx context.Context) (bool, error) {
+			jobs := &ansiblejobv1alpha1.AnsibleJobList{}
+			if err := hub.List(ctx, jobs, client.InNamespace(clusterName)); err != nil {
+				return false, fmt.Errorf("failed to list ansible jobs for cluster %s: %w", clusterName, err)
+			}
+			for _, hook := range hooks {
+				job := findAnsibleJobForTemplate(jobs, hook.TemplateName)
+				if job == nil {
+					lastErr = fmt.Errorf("no AnsibleJob launched for hook template %s", hook.TemplateName)
+					return false, nil
+				}
+				switch job.Status.AnsibleJobResult.Status {
+				case "successful":
+					continue
+				case "failed", "error", "canceled":
+					return false, fmt.Errorf("AnsibleJob %s for hook template %s finished %s",
+						job.Name, hook.TemplateName, job.Status.AnsibleJobResult.Status)
+				default:
+					lastErr = fmt.Errorf("AnsibleJob %s for hook template %s still %q",
+						job.Name, hook.TemplateName, job.Status.AnsibleJobResult.Status)
+					return false, nil
+				}
+			}
+			return true, nil
+		})
+	if err != nil && lastErr != nil {
+		return fmt.Errorf("%w: %v", err, lastErr)
+	}
+	return err
+}
+
+// findAnsibleJobForTemplate returns the newest AnsibleJob created for a
+// given Tower job template name, or nil when none exists.
+func findAnsibleJobForTemplate(jobs *ansiblejobv1alpha1.AnsibleJobList, templateName string) *ansiblejobv1alpha1.AnsibleJob {
+	var newest *ansiblejobv1alpha1.AnsibleJob
+	for i := range jobs.Items {
+		job := &jobs.Items[i]
+		if job.Spec.JobTemplateName != templateName {
+			continue
+		}
+		if newest == nil || job.CreationTimestamp.After(newest.CreationTimestamp.Time) {
+			newest = job
+		}
+	}
+	return newest
+}
@@ -0,0 +1,45 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package clusters
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestToCuratorHooksExtraVars(t *testing.T) {
+	hooks, err := toCuratorHooks([]CuratorHook{
+		{TemplateName: "pre", ExtraVars: map[string]interface{}{"limit": "infra", "retries": 3}},
+		{TemplateName: "bare"},
+	})
+	if err != nil {
+		t.Fatalf("toCuratorHooks: %v", err)
+	}
+	if len(hooks) != 2 || hooks[0].Name != "pre" || hooks[1].Name != "bare" {
+		t.Fatalf("hooks = %+v", hooks)
+	}
+
+	if hooks[0].ExtraVars == nil {
+		t.Fatal("extra vars were dropped in the conversion")
+	}
+	vars := map[string]interface{}{}
+	if err := json.Unmarshal(hooks[0].ExtraVars.Raw, &vars); err != nil {
+		t.Fatalf("failed to decode converted extra vars: %v", err)
+	}
+	if vars["limit"] != "infra" || vars["retries"] != float64(3) {
+		t.Errorf("converted extra vars = %v", vars)
+	}
+
+	if hooks[1].ExtraVars != nil {
+		t.Errorf("hook without extra vars got %v", hooks[1].ExtraVars)
+	}
+}
+
+func TestToCuratorHooksUnmarshalableExtraVars(t *testing.T) {
+	_, err := toCuratorHooks([]CuratorHook{
+		{TemplateName: "pre", ExtraVars: map[string]interface{}{"ch": make(chan int)}},
+	})
+	if err == nil {
+		t.Fatal("unmarshalable extra vars were accepted")
+	}
+}
@@ -0,0 +1,122 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package clusters
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const deprovisionTimeout = 45 * time.Minute
+
+// DestroyCluster deletes the ClusterDeployment and waits for Hive to finish
+// the cloud deprovision and remove the object.
+func DestroyCluster(ctx context.Context, hub client.Client, clusterName string) error {
+	deployment := &hivev1.ClusterDeployment{
+		ObjectMeta: metav1.ObjectMeta{Namespace: clusterName, Name: clusterName},
+	}
+	if err := hub.Delete(ctx, deployment); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to delete cluster deployment %s: %w", clusterName, err)
+	}
+	return waitForClusterDeploymentGone(ctx, hub, clusterName, deprovisionTimeout)
+}
+
+func waitForClusterDeploymentGone(ctx context.Context, hub client.Client, clusterName string, timeout time.Duration) error {
+	deployment := &hivev1.ClusterDeployment{}
+	return wait.PollUntilContextTimeout(ctx, createClusterPollInterval, timeout, true,
+		func(ctx context.Context) (bool, error) {
+			err := hub.Get(ctx, client.ObjectKey{Namespace: clusterName, Name: clusterName}, deployment)
+			if apierrors.IsNotFound(err) {
+				return true, nil
+			}
+			return false, err
+		})
+}
+
+// ForceCleanupReport lists what DestroyClusterForce had to remove by hand.
+// A non-empty report means cloud resources may have been orphaned and the
+// deprovision failure should be investigated.
+type ForceCleanupReport struct {
+	// FinalizersRemoved maps object descriptions to the finalizers that
+	// were stripped.
+	FinalizersRemoved map[string][]string
+	// DeprovisionsDeleted lists ClusterDeprovision objects removed.
+	DeprovisionsDeleted []string
+}
+
+// Empty reports whether no force-cleanup was necessary.
+func (r *ForceCleanupReport) Empty() bool {
+	return len(r.FinalizersRemoved) == 0 && len(r.DeprovisionsDeleted) == 0
+}
+
+// DestroyClusterForce destroys a cluster like DestroyCluster but, when the
+// deprovision does not finish within gracePeriod, strips finalizers from
+// the ClusterDeployment and deletes the hive deprovision objects so a stuck
+// cloud deprovision cannot wedge the run. The returned report says exactly
+// what was forced; callers should surface it in the test output.
+func DestroyClusterForce(ctx context.Context, hub client.Client, clusterName string, gracePeriod time.Duration) (*ForceCleanupReport, error) {
+	report := &ForceCleanupReport{FinalizersRemoved: map[string][]string{}}
+
+	deployment := &hivev1.ClusterDeployment{
+		ObjectMeta: metav1.ObjectMeta{Namespace: clusterName, Name: clusterName},
+	}
+	if err := hub.Delete(ctx, deployment); err != nil && !apierrors.IsNotFound(err) {
+		return nil, fmt.Errorf("failed to delete cluster deployment %s: %w", clusterName, err)
+	}
+	if err := waitForClusterDeploymentGone(ctx, hub, clusterName, gracePeriod); err == nil {
+		return report, nil
+	}
+
+	// Graceful deprovision timed out; force it.
+	if err := hub.Get(ctx, client.ObjectKey{Namespace: clusterName, Name: clusterName}, deployment); err == nil {
+		if len(deployment.Finalizers) > 0 {
+			report.FinalizersRemoved[fmt.Sprintf("clusterdeployment/%s", clusterName)] = deployment.Finalizers
+			deployment.Finalizers = nil
+			if err := hub.Update(ctx, deployment); err != nil && !apierrors.IsNotFound(err) {
+				return report, fmt.Errorf("failed to strip finalizers from cluster deployment %s: %w", clusterName, err)
+			}
+		}
+	} else if !apierrors.IsNotFound(err) {
+		return report, fmt.Errorf("failed to re-check cluster deployment %s: %w", clusterName, err)
+	}
+
+	deprovisions := &hivev1.ClusterDeprovisionList{}
+	if err := hub.List(ctx, deprovisions, client.InNamespace(clusterName)); err != nil {
+		return report, fmt.Errorf("failed to list deprovisions of cluster %s: %w", clusterName, err)
+	}
+	for i := range deprovisions.Items {
+		deprovision := &deprovisions.Items[i]
+		if len(deprovision.Finalizers) > 0 {
+			report.FinalizersRemoved[fmt.Sprintf("clusterdeprovision/%s", deprovision.Name)] = deprovision.Finalizers
+			deprovision.Finalizers = nil
+			if err := hub.Update(ctx, deprovision); err != nil && !apierrors.IsNotFound(err) {
+				return report, fmt.Errorf("failed to strip finalizers from deprovision %s: %w", deprovision.Name, err)
+			}
+		}
+		if err := hub.Delete(ctx, deprovision); err != nil && !apierrors.IsNotFound(err) {
+			return report, fmt.Errorf("failed to delete deprovision %s: %w", deprovision.Name, err)
+		}
+		report.DeprovisionsDeleted = append(report.DeprovisionsDeleted, deprovision.Name)
+	}
+
+	// The namespace should now be able to terminate.
+	namespace := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: clusterName}}
+	if err := hub.Delete(ctx, namespace); err != nil && !apierrors.IsNotFound(err) {
+		return report, fmt.Errorf("failed to delete namespace of cluster %s: %w", clusterName, err)
+	}
+	if err := waitForNamespaceGone(ctx, hub, clusterName); err != nil {
+		return report, err
+	}
+	return report, nil
+}
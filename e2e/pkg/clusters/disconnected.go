@@ -0,0 +1,122 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package clusters
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	klusterletconfigv1alpha1 "github.com/stolostron/cluster-lifecycle-api/klusterletconfig/v1alpha1"
+	appsv1 "k8s.io/api/apps/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// RegistryMirror maps an upstream image source to one or more mirrors inside
+// the disconnected environment, mirroring the semantics of
+// ImageContentSourcePolicy / ImageDigestMirrorSet entries.
+type RegistryMirror struct {
+	Source  string
+	Mirrors []string
+}
+
+// DisconnectedImportOptions extends an import with the settings a
+// disconnected managed cluster needs: registry mirrors for the klusterlet
+// images and a CA bundle trusted by the mirror registry and the hub API.
+type DisconnectedImportOptions struct {
+	ClusterName string
+	Mirrors     []RegistryMirror
+	// CABundle is appended to the klusterlet's trust store, typically the
+	// mirror registry CA plus the hub API serving CA.
+	CABundle []byte
+}
+
+// disconnectedConfigName derives the per-cluster KlusterletConfig name used
+// for disconnected settings.
+func disconnectedConfigName(clusterName string) string {
+	return fmt.Sprintf("%s-disconnected", clusterName)
+}
+
+// ConfigureDisconnectedImport creates a KlusterletConfig carrying the image
+// mirrors and CA bundle and binds it to the cluster, so that a subsequent
+// (re-)import renders klusterlet manifests that work air-gapped.
+func ConfigureDisconnectedImport(ctx context.Context, hub client.Client, opts DisconnectedImportOptions) error {
+	if len(opts.Mirrors) == 0 && len(opts.CABundle) == 0 {
+		return fmt.Errorf("disconnected import for cluster %s requires mirrors or a CA bundle", opts.ClusterName)
+	}
+
+	registries := make([]klusterletconfigv1alpha1.Registries, 0, len(opts.Mirrors))
+	for _, mirror := range opts.Mirrors {
+		if len(mirror.Mirrors) == 0 {
+			return fmt.Errorf("registry mirror for source %s has no mirror registries", mirror.Source)
+		}
+		// KlusterletConfig carries a single mirror per source; additional
+		// mirrors become additional entries so ordering expresses priority.
+		for _, m := range mirror.Mirrors {
+			registries = append(registries, klusterletconfigv1alpha1.Registries{
+				Source: mirror.Source,
+				Mirror: m,
+			})
+		}
+	}
+
+	config, err := CreateKlusterletConfig(ctx, hub, KlusterletConfigOptions{
+		Name:       disconnectedConfigName(opts.ClusterName),
+		Registries: registries,
+	})
+	if err != nil {
+		return err
+	}
+	if len(opts.CABundle) > 0 {
+		config.Spec.HubKubeAPIServerCABundle = opts.CABundle
+		if err := hub.Update(ctx, config); err != nil {
+			return fmt.Errorf("failed to set CA bundle on klusterlet config %s: %w", config.Name, err)
+		}
+	}
+
+	return BindKlusterletConfig(ctx, hub, opts.ClusterName, config.Name)
+}
+
+// VerifyMirroredKlusterletImages asserts that every klusterlet deployment on
+// the spoke pulls its images from one of the configured mirrors instead of
+// the upstream source registries.
+func VerifyMirroredKlusterletImages(ctx context.Context, spoke client.Client, mirrors []RegistryMirror) error {
+	sources := make([]string, 0, len(mirrors))
+	for _, mirror := range mirrors {
+		sources = append(sources, mirror.Source)
+	}
+	return forEachKlusterletImage(ctx, spoke, func(deployment, image string) error {
+		for _, source := range sources {
+			if hasRegistryPrefix(image, source) {
+				return fmt.Errorf("deployment %s still pulls %s from unmirrored source %s", deployment, image, source)
+			}
+		}
+		return nil
+	})
+}
+
+// forEachKlusterletImage calls check for every container image of every
+// klusterlet deployment on the spoke.
+func forEachKlusterletImage(ctx context.Context, spoke client.Client, check func(deployment, image string) error) error {
+	deployments := &appsv1.DeploymentList{}
+	if err := spoke.List(ctx, deployments, client.InNamespace(klusterletNamespace)); err != nil {
+		return fmt.Errorf("failed to list klusterlet deployments: %w", err)
+	}
+	if len(deployments.Items) == 0 {
+		return fmt.Errorf("no deployments found in %s", klusterletNamespace)
+	}
+	for _, deployment := range deployments.Items {
+		for _, container := range deployment.Spec.Template.Spec.Containers {
+			if err := check(deployment.Name, container.Image); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// hasRegistryPrefix reports whether image is pulled from the given registry
+// or repository prefix, respecting path component boundaries.
+func hasRegistryPrefix(image, prefix string) bool {
+	return image == prefix || strings.HasPrefix(image, prefix+"/") || strings.HasPrefix(image, prefix+"@") || strings.HasPrefix(image, prefix+":")
+}
@@ -0,0 +1,50 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package clusters
+
+import (
+	"context"
+	"fmt"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// GCPSharedVPCOptions describes a shared VPC (XPN) install where networking
+// lives in a host project and the cluster runs in a service project.
+type GCPSharedVPCOptions struct {
+	CreateClusterOptions
+	// NetworkProjectID is the host project owning the VPC.
+	NetworkProjectID string
+	// Network is the shared VPC network name in the host project.
+	Network string
+	// ComputeSubnet and ControlPlaneSubnet are subnets of the shared
+	// network delegated to the service project.
+	ComputeSubnet      string
+	ControlPlaneSubnet string
+}
+
+// CreateGCPSharedVPCCluster provisions a GCP cluster into a shared VPC.
+// The service account in the credentials secret needs the Compute Network
+// User role on the host project's subnets.
+func CreateGCPSharedVPCCluster(ctx context.Context, hub client.Client, opts GCPSharedVPCOptions) (*hivev1.ClusterDeployment, error) {
+	if opts.Cloud != "gcp" {
+		return nil, fmt.Errorf("shared VPC clusters are GCP-only, got cloud %q", opts.Cloud)
+	}
+	if opts.NetworkProjectID == "" || opts.Network == "" {
+		return nil, fmt.Errorf("shared VPC cluster %s requires a host project and network", opts.Name)
+	}
+	if opts.ComputeSubnet == "" || opts.ControlPlaneSubnet == "" {
+		return nil, fmt.Errorf("shared VPC cluster %s requires compute and control plane subnets", opts.Name)
+	}
+
+	withSharedVPC := opts.CreateClusterOptions
+	withSharedVPC.Overrides = opts.Overrides
+	return createClusterWithConfig(ctx, hub, withSharedVPC, func(config map[string]interface{}) {
+		platform := config["platform"].(map[string]interface{})["gcp"].(map[string]interface{})
+		platform["networkProjectID"] = opts.NetworkProjectID
+		platform["network"] = opts.Network
+		platform["computeSubnet"] = opts.ComputeSubnet
+		platform["controlPlaneSubnet"] = opts.ControlPlaneSubnet
+	})
+}
@@ -0,0 +1,124 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package clusters
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// imageSetVersionPattern extracts "major.minor.patch" plus any pre-release
+// suffix from ClusterImageSet names like "img4.15.8-x86-64-appsub" or
+// release images like "...:4.16.0-0.nightly-2024-05-01-120000".
+var imageSetVersionPattern = regexp.MustCompile(`(\d+)\.(\d+)\.(\d+)(-[0-9A-Za-z.-]+)?`)
+
+// CreateClusterImageSet creates a ClusterImageSet pointing at a release
+// image.
+func CreateClusterImageSet(ctx context.Context, hub client.Client, name, releaseImage string) (*hivev1.ClusterImageSet, error) {
+	imageSet := &hivev1.ClusterImageSet{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec:       hivev1.ClusterImageSetSpec{ReleaseImage: releaseImage},
+	}
+	if err := hub.Create(ctx, imageSet); err != nil && !apierrors.IsAlreadyExists(err) {
+		return nil, fmt.Errorf("failed to create cluster image set %s: %w", name, err)
+	}
+	return imageSet, nil
+}
+
+// ListClusterImageSets returns all ClusterImageSets on the hub.
+func ListClusterImageSets(ctx context.Context, hub client.Client) ([]hivev1.ClusterImageSet, error) {
+	imageSets := &hivev1.ClusterImageSetList{}
+	if err := hub.List(ctx, imageSets); err != nil {
+		return nil, fmt.Errorf("failed to list cluster image sets: %w", err)
+	}
+	return imageSets.Items, nil
+}
+
+// imageSetVersion is a parsed image set version for ordering.
+type imageSetVersion struct {
+	imageSet   hivev1.ClusterImageSet
+	major      int
+	minor      int
+	patch      int
+	prerelease string
+}
+
+func parseImageSetVersion(imageSet hivev1.ClusterImageSet) (imageSetVersion, bool) {
+	source := imageSet.Name
+	match := imageSetVersionPattern.FindStringSubmatch(source)
+	if match == nil {
+		source = imageSet.Spec.ReleaseImage
+		match = imageSetVersionPattern.FindStringSubmatch(source)
+	}
+	if match == nil {
+		return imageSetVersion{}, false
+	}
+	major, _ := strconv.Atoi(match[1])
+	minor, _ := strconv.Atoi(match[2])
+	patch, _ := strconv.Atoi(match[3])
+	return imageSetVersion{
+		imageSet:   imageSet,
+		major:      major,
+		minor:      minor,
+		patch:      patch,
+		prerelease: strings.TrimPrefix(match[4], "-"),
+	}, true
+}
+
+// LatestClusterImageSet returns the newest ClusterImageSet for an OpenShift
+// minor version given as "4.y". Nightly and CI payloads are included when
+// includePrerelease is set; otherwise only released payloads are
+// considered. Among equal patch versions a released payload wins over a
+// pre-release one.
+func LatestClusterImageSet(ctx context.Context, hub client.Client, minorVersion string, includePrerelease bool) (*hivev1.ClusterImageSet, error) {
+	parts := strings.SplitN(minorVersion, ".", 2)
+	if len(parts) != 2 {
+		return nil, fmt.Errorf("minor version must look like \"4.15\", got %q", minorVersion)
+	}
+	major, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return nil, fmt.Errorf("invalid major version in %q: %w", minorVersion, err)
+	}
+	minor, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return nil, fmt.Errorf("invalid minor version in %q: %w", minorVersion, err)
+	}
+
+	imageSets, err := ListClusterImageSets(ctx, hub)
+	if err != nil {
+		return nil, err
+	}
+	var candidates []imageSetVersion
+	for _, imageSet := range imageSets {
+		version, ok := parseImageSetVersion(imageSet)
+		if !ok || version.major != major || version.minor != minor {
+			continue
+		}
+		if version.prerelease != "" && !includePrerelease {
+			continue
+		}
+		candidates = append(candidates, version)
+	}
+	if len(candidates) == 0 {
+		return nil, fmt.Errorf("no cluster image set found for OpenShift %s (prerelease=%t)", minorVersion, includePrerelease)
+	}
+
+	sort.Slice(candidates, func(i, j int) bool {
+		if candidates[i].patch != candidates[j].patch {
+			return candidates[i].patch < candidates[j].patch
+		}
+		// Released sorts after pre-release at equal patch level.
+		return candidates[i].prerelease != "" && candidates[j].prerelease == ""
+	})
+	latest := candidates[len(candidates)-1].imageSet
+	return &latest, nil
+}
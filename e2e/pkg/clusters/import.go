@@ -0,0 +1,164 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package clusters
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	clusterv1 "open-cluster-management.io/api/cluster/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	detachPollInterval = 15 * time.Second
+	detachWaitTimeout  = 15 * time.Minute
+)
+
+// ImportOptions describes a cluster to import into the hub.
+type ImportOptions struct {
+	ClusterName string
+	// Kubeconfig grants admin access to the cluster being imported and
+	// feeds the auto-import secret.
+	Kubeconfig  []byte
+	Labels      map[string]string
+	Annotations map[string]string
+}
+
+// ImportCluster imports an existing cluster: it creates the ManagedCluster
+// with hubAcceptsClient set, writes the auto-import secret and waits until
+// the cluster joins and becomes available.
+//
+// The call is idempotent. When the cluster is already managed and
+// available, it returns immediately without touching the registration or
+// the auto-import secret, so suites can share an imported cluster. When a
+// ManagedCluster exists but never became available, the import is resumed:
+// the auto-import secret is rewritten and the wait restarted.
+func ImportCluster(ctx context.Context, hub client.Client, opts ImportOptions) (*clusterv1.ManagedCluster, error) {
+	existing := &clusterv1.ManagedCluster{}
+	err := hub.Get(ctx, client.ObjectKey{Name: opts.ClusterName}, existing)
+	switch {
+	case err == nil:
+		if meta.IsStatusConditionTrue(existing.Status.Conditions, clusterv1.ManagedClusterConditionAvailable) {
+			return existing, nil
+		}
+		if !existing.DeletionTimestamp.IsZero() {
+			return nil, fmt.Errorf("cluster %s is being detached; re-import after the detach finishes", opts.ClusterName)
+		}
+		// Fall through to resume the half-finished import.
+	case !apierrors.IsNotFound(err):
+		return nil, fmt.Errorf("failed to check for existing managed cluster %s: %w", opts.ClusterName, err)
+	}
+
+	cluster := &clusterv1.ManagedCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        opts.ClusterName,
+			Labels:      opts.Labels,
+			Annotations: opts.Annotations,
+		},
+		Spec: clusterv1.ManagedClusterSpec{
+			HubAcceptsClient: true,
+		},
+	}
+	if err := hub.Create(ctx, cluster); err != nil && !apierrors.IsAlreadyExists(err) {
+		return nil, fmt.Errorf("failed to create managed cluster %s: %w", opts.ClusterName, err)
+	}
+
+	if err := CreateAutoImportSecret(ctx, hub, opts.ClusterName, opts.Kubeconfig); err != nil {
+		return nil, err
+	}
+	if err := WaitForClusterJoined(ctx, hub, opts.ClusterName); err != nil {
+		return nil, err
+	}
+	if err := hub.Get(ctx, client.ObjectKey{Name: opts.ClusterName}, cluster); err != nil {
+		return nil, fmt.Errorf("failed to get managed cluster %s: %w", opts.ClusterName, err)
+	}
+	return cluster, nil
+}
+
+// WaitForClusterJoined waits until the ManagedCluster reports both Joined
+// and Available.
+func WaitForClusterJoined(ctx context.Context, hub client.Client, clusterName string) error {
+	cluster := &clusterv1.ManagedCluster{}
+	err := wait.PollUntilContextTimeout(ctx, autoImportPollInterval, autoImportWaitTimeout, true,
+		func(ctx context.Context) (bool, error) {
+			if err := hub.Get(ctx, client.ObjectKey{Name: clusterName}, cluster); err != nil {
+				if apierrors.IsNotFound(err) {
+					return false, nil
+				}
+				return false, err
+			}
+			joined := meta.IsStatusConditionTrue(cluster.Status.Conditions, clusterv1.ManagedClusterConditionJoined)
+			available := meta.IsStatusConditionTrue(cluster.Status.Conditions, clusterv1.ManagedClusterConditionAvailable)
+			return joined && available, nil
+		})
+	if err != nil {
+		return fmt.Errorf("cluster %s did not join the hub: %w", clusterName, err)
+	}
+	return nil
+}
+
+// DetachCluster removes the ManagedCluster from the hub and waits until the
+// object is gone, which includes the klusterlet being torn down on the
+// spoke.
+func DetachCluster(ctx context.Context, hub client.Client, clusterName string) error {
+	cluster := &clusterv1.ManagedCluster{ObjectMeta: metav1.ObjectMeta{Name: clusterName}}
+	if err := hub.Delete(ctx, cluster); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to detach cluster %s: %w", clusterName, err)
+	}
+
+	err := wait.PollUntilContextTimeout(ctx, detachPollInterval, detachWaitTimeout, true,
+		func(ctx context.Context) (bool, error) {
+			err := hub.Get(ctx, client.ObjectKey{Name: clusterName}, cluster)
+			if apierrors.IsNotFound(err) {
+				return true, nil
+			}
+			return false, err
+		})
+	if err != nil {
+		return fmt.Errorf("cluster %s was not fully detached: %w", clusterName, err)
+	}
+	return nil
+}
+
+// DetachAndReimport detaches a managed cluster and imports it again with
+// the same options, the canonical recovery flow for a broken registration.
+// The target cluster keeps running throughout; only the hub registration is
+// recycled.
+func DetachAndReimport(ctx context.Context, hub client.Client, opts ImportOptions) (*clusterv1.ManagedCluster, error) {
+	if err := DetachCluster(ctx, hub, opts.ClusterName); err != nil {
+		return nil, err
+	}
+	// The cluster namespace is deleted asynchronously with the
+	// ManagedCluster; wait for it so the re-import does not race namespace
+	// termination.
+	if err := waitForNamespaceGone(ctx, hub, opts.ClusterName); err != nil {
+		return nil, err
+	}
+	return ImportCluster(ctx, hub, opts)
+}
+
+func waitForNamespaceGone(ctx context.Context, hub client.Client, name string) error {
+	namespace := &corev1.Namespace{}
+	err := wait.PollUntilContextTimeout(ctx, detachPollInterval, detachWaitTimeout, true,
+		func(ctx context.Context) (bool, error) {
+			err := hub.Get(ctx, client.ObjectKey{Name: name}, namespace)
+			if apierrors.IsNotFound(err) {
+				return true, nil
+			}
+			return false, err
+		})
+	if err != nil {
+		return fmt.Errorf("namespace %s was not removed after detach: %w", name, err)
+	}
+	return nil
+}
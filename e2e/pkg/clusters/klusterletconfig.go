@@ -0,0 +1,133 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package clusters
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"time"
+
+	klusterletconfigv1alpha1 "github.com/stolostron/cluster-lifecycle-api/klusterletconfig/v1alpha1"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	clusterv1 "open-cluster-management.io/api/cluster/v1"
+	operatorv1 "open-cluster-management.io/api/operator/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// klusterletConfigAnnotation binds an imported cluster to a
+	// KlusterletConfig by name.
+	klusterletConfigAnnotation = "agent.open-cluster-management.io/klusterlet-config"
+
+	// klusterletNamespace hosts the klusterlet agent deployments on the spoke.
+	klusterletNamespace = "open-cluster-management-agent"
+
+	klusterletConfigTimeout = 10 * time.Minute
+)
+
+// KlusterletConfigOptions holds the customizations to apply through a
+// KlusterletConfig resource.
+type KlusterletConfigOptions struct {
+	Name         string
+	NodeSelector map[string]string
+	Tolerations  []corev1.Toleration
+	// Registries mirrors klusterlet images from the given sources.
+	Registries []klusterletconfigv1alpha1.Registries
+	// ProxyConfig routes hub traffic of the agent through a proxy.
+	ProxyConfig *klusterletconfigv1alpha1.KubeAPIServerProxyConfig
+}
+
+// CreateKlusterletConfig creates a KlusterletConfig on the hub.
+func CreateKlusterletConfig(ctx context.Context, hub client.Client, opts KlusterletConfigOptions) (*klusterletconfigv1alpha1.KlusterletConfig, error) {
+	config := &klusterletconfigv1alpha1.KlusterletConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: opts.Name},
+		Spec: klusterletconfigv1alpha1.KlusterletConfigSpec{
+			NodePlacement: &operatorv1.NodePlacement{
+				NodeSelector: opts.NodeSelector,
+				Tolerations:  opts.Tolerations,
+			},
+			Registries: opts.Registries,
+		},
+	}
+	if opts.ProxyConfig != nil {
+		config.Spec.HubKubeAPIServerProxyConfig = *opts.ProxyConfig
+	}
+	if err := hub.Create(ctx, config); err != nil && !apierrors.IsAlreadyExists(err) {
+		return nil, fmt.Errorf("failed to create klusterlet config %s: %w", opts.Name, err)
+	}
+	return config, nil
+}
+
+// BindKlusterletConfig annotates an imported ManagedCluster with the
+// KlusterletConfig name so the import controller regenerates the klusterlet
+// manifests with the customizations.
+func BindKlusterletConfig(ctx context.Context, hub client.Client, clusterName, configName string) error {
+	cluster := &clusterv1.ManagedCluster{}
+	if err := hub.Get(ctx, client.ObjectKey{Name: clusterName}, cluster); err != nil {
+		return fmt.Errorf("failed to get managed cluster %s: %w", clusterName, err)
+	}
+	if cluster.Annotations == nil {
+		cluster.Annotations = map[string]string{}
+	}
+	cluster.Annotations[klusterletConfigAnnotation] = configName
+	if err := hub.Update(ctx, cluster); err != nil {
+		return fmt.Errorf("failed to bind klusterlet config %s to cluster %s: %w", configName, clusterName, err)
+	}
+	return nil
+}
+
+// VerifyKlusterletCustomization waits until every klusterlet deployment on
+// the spoke reflects the node selector and tolerations of the config.
+func VerifyKlusterletCustomization(ctx context.Context, spoke client.Client, opts KlusterletConfigOptions) error {
+	var lastErr error
+	err := wait.PollUntilContextTimeout(ctx, addonPollInterval, klusterletConfigTimeout, true,
+		func(ctx context.Context) (bool, error) {
+			deployments := &appsv1.DeploymentList{}
+			if err := spoke.List(ctx, deployments, client.InNamespace(klusterletNamespace)); err != nil {
+				return false, fmt.Errorf("failed to list klusterlet deployments: %w", err)
+			}
+			if len(deployments.Items) == 0 {
+				lastErr = fmt.Errorf("no deployments found in %s", klusterletNamespace)
+				return false, nil
+			}
+			for _, deployment := range deployments.Items {
+				if err := deploymentMatchesPlacement(&deployment, opts.NodeSelector, opts.Tolerations); err != nil {
+					lastErr = err
+					return false, nil
+				}
+			}
+			return true, nil
+		})
+	if err != nil && lastErr != nil {
+		return fmt.Errorf("klusterlet customization not applied: %w: %v", err, lastErr)
+	}
+	return err
+}
+
+func deploymentMatchesPlacement(deployment *appsv1.Deployment, nodeSelector map[string]string, tolerations []corev1.Toleration) error {
+	podSpec := deployment.Spec.Template.Spec
+	for k, v := range nodeSelector {
+		if podSpec.NodeSelector[k] != v {
+			return fmt.Errorf("deployment %s node selector %s=%q, expected %q",
+				deployment.Name, k, podSpec.NodeSelector[k], v)
+		}
+	}
+	for _, want := range tolerations {
+		found := false
+		for _, toleration := range podSpec.Tolerations {
+			if reflect.DeepEqual(toleration, want) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return fmt.Errorf("deployment %s is missing toleration for key %q", deployment.Name, want.Key)
+		}
+	}
+	return nil
+}
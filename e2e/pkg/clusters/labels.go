@@ -0,0 +1,96 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package clusters
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	clusterv1 "open-cluster-management.io/api/cluster/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// mutateManagedCluster applies mutate to the ManagedCluster with a
+// conflict-retrying update, since registration controllers update cluster
+// metadata concurrently.
+func mutateManagedCluster(ctx context.Context, hub client.Client, clusterName string, mutate func(*clusterv1.ManagedCluster)) error {
+	for {
+		cluster := &clusterv1.ManagedCluster{}
+		if err := hub.Get(ctx, client.ObjectKey{Name: clusterName}, cluster); err != nil {
+			return fmt.Errorf("failed to get managed cluster %s: %w", clusterName, err)
+		}
+		mutate(cluster)
+		err := hub.Update(ctx, cluster)
+		if err == nil {
+			return nil
+		}
+		if !errors.IsConflict(err) {
+			return fmt.Errorf("failed to update managed cluster %s: %w", clusterName, err)
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+	}
+}
+
+// SetClusterLabels adds or overwrites labels on a ManagedCluster.
+func SetClusterLabels(ctx context.Context, hub client.Client, clusterName string, labels map[string]string) error {
+	return mutateManagedCluster(ctx, hub, clusterName, func(cluster *clusterv1.ManagedCluster) {
+		if cluster.Labels == nil {
+			cluster.Labels = map[string]string{}
+		}
+		for k, v := range labels {
+			cluster.Labels[k] = v
+		}
+	})
+}
+
+// RemoveClusterLabels deletes the named labels from a ManagedCluster.
+// Missing labels are ignored.
+func RemoveClusterLabels(ctx context.Context, hub client.Client, clusterName string, keys ...string) error {
+	return mutateManagedCluster(ctx, hub, clusterName, func(cluster *clusterv1.ManagedCluster) {
+		for _, key := range keys {
+			delete(cluster.Labels, key)
+		}
+	})
+}
+
+// SetClusterAnnotations adds or overwrites annotations on a ManagedCluster.
+func SetClusterAnnotations(ctx context.Context, hub client.Client, clusterName string, annotations map[string]string) error {
+	return mutateManagedCluster(ctx, hub, clusterName, func(cluster *clusterv1.ManagedCluster) {
+		if cluster.Annotations == nil {
+			cluster.Annotations = map[string]string{}
+		}
+		for k, v := range annotations {
+			cluster.Annotations[k] = v
+		}
+	})
+}
+
+// RemoveClusterAnnotations deletes the named annotations from a
+// ManagedCluster. Missing annotations are ignored.
+func RemoveClusterAnnotations(ctx context.Context, hub client.Client, clusterName string, keys ...string) error {
+	return mutateManagedCluster(ctx, hub, clusterName, func(cluster *clusterv1.ManagedCluster) {
+		for _, key := range keys {
+			delete(cluster.Annotations, key)
+		}
+	})
+}
+
+// VerifyClusterLabels asserts that the ManagedCluster carries exactly the
+// given label values; labels outside the map are not checked.
+func VerifyClusterLabels(ctx context.Context, hub client.Client, clusterName string, expected map[string]string) error {
+	cluster := &clusterv1.ManagedCluster{}
+	if err := hub.Get(ctx, client.ObjectKey{Name: clusterName}, cluster); err != nil {
+		return fmt.Errorf("failed to get managed cluster %s: %w", clusterName, err)
+	}
+	for k, v := range expected {
+		if got := cluster.Labels[k]; got != v {
+			return fmt.Errorf("cluster %s has label %s=%q, expected %q", clusterName, k, got, v)
+		}
+	}
+	return nil
+}
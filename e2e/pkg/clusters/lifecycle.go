@@ -0,0 +1,149 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package clusters
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// LifecycleStage is a checkpoint in the cluster lifecycle orchestration.
+type LifecycleStage string
+
+const (
+	StageCreated   LifecycleStage = "created"
+	StageInstalled LifecycleStage = "installed"
+	StageImported  LifecycleStage = "imported"
+	StageVerified  LifecycleStage = "verified"
+)
+
+// stageOrder defines progression; a recorded stage implies all earlier
+// stages completed.
+var stageOrder = []LifecycleStage{StageCreated, StageInstalled, StageImported, StageVerified}
+
+// lifecycleConfigMapName stores per-cluster lifecycle progress.
+const lifecycleConfigMapName = "e2e-lifecycle-state"
+
+// LifecycleState records lifecycle progress per cluster in a ConfigMap on
+// the hub, so an interrupted run can resume at the failed step instead of
+// re-provisioning everything.
+type LifecycleState struct {
+	hub       client.Client
+	namespace string
+}
+
+// NewLifecycleState returns a state store backed by a ConfigMap in the
+// given namespace, typically the run's working namespace.
+func NewLifecycleState(hub client.Client, namespace string) *LifecycleState {
+	return &LifecycleState{hub: hub, namespace: namespace}
+}
+
+func (s *LifecycleState) key() client.ObjectKey {
+	return client.ObjectKey{Namespace: s.namespace, Name: lifecycleConfigMapName}
+}
+
+// Get returns the recorded stage of a cluster; the empty stage means no
+// progress has been recorded.
+func (s *LifecycleState) Get(ctx context.Context, clusterName string) (LifecycleStage, error) {
+	configMap := &corev1.ConfigMap{}
+	if err := s.hub.Get(ctx, s.key(), configMap); err != nil {
+		if apierrors.IsNotFound(err) {
+			return "", nil
+		}
+		return "", fmt.Errorf("failed to read lifecycle state: %w", err)
+	}
+	return LifecycleStage(configMap.Data[clusterName]), nil
+}
+
+// Record stores the stage a cluster has reached.
+func (s *LifecycleState) Record(ctx context.Context, clusterName string, stage LifecycleStage) error {
+	configMap := &corev1.ConfigMap{}
+	err := s.hub.Get(ctx, s.key(), configMap)
+	if apierrors.IsNotFound(err) {
+		configMap = &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Namespace: s.namespace, Name: lifecycleConfigMapName},
+			Data:       map[string]string{clusterName: string(stage)},
+		}
+		if err := s.hub.Create(ctx, configMap); err != nil {
+			return fmt.Errorf("failed to create lifecycle state: %w", err)
+		}
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read lifecycle state: %w", err)
+	}
+	if configMap.Data == nil {
+		configMap.Data = map[string]string{}
+	}
+	configMap.Data[clusterName] = string(stage)
+	if err := s.hub.Update(ctx, configMap); err != nil {
+		return fmt.Errorf("failed to record lifecycle stage %s for cluster %s: %w", stage, clusterName, err)
+	}
+	return nil
+}
+
+// Clear removes a cluster's recorded progress, typically after destroy.
+func (s *LifecycleState) Clear(ctx context.Context, clusterName string) error {
+	configMap := &corev1.ConfigMap{}
+	if err := s.hub.Get(ctx, s.key(), configMap); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to read lifecycle state: %w", err)
+	}
+	delete(configMap.Data, clusterName)
+	if err := s.hub.Update(ctx, configMap); err != nil {
+		return fmt.Errorf("failed to clear lifecycle state of cluster %s: %w", clusterName, err)
+	}
+	return nil
+}
+
+// stageReached reports whether recorded already covers stage.
+func stageReached(recorded, stage LifecycleStage) bool {
+	recordedIndex := -1
+	stageIndex := -1
+	for i, s := range stageOrder {
+		if s == recorded {
+			recordedIndex = i
+		}
+		if s == stage {
+			stageIndex = i
+		}
+	}
+	return recordedIndex >= stageIndex && stageIndex >= 0
+}
+
+// LifecycleStep performs the work to bring a cluster to one stage.
+type LifecycleStep func(ctx context.Context) error
+
+// Resume runs the lifecycle steps for a cluster in order, skipping every
+// stage the state store already records as complete and checkpointing after
+// each successful step. On failure the recorded state is left at the last
+// completed stage, so the next run resumes exactly at the failed step.
+func (s *LifecycleState) Resume(ctx context.Context, clusterName string, steps map[LifecycleStage]LifecycleStep) error {
+	recorded, err := s.Get(ctx, clusterName)
+	if err != nil {
+		return err
+	}
+	for _, stage := range stageOrder {
+		step, ok := steps[stage]
+		if !ok {
+			continue
+		}
+		if recorded != "" && stageReached(recorded, stage) {
+			continue
+		}
+		if err := step(ctx); err != nil {
+			return fmt.Errorf("lifecycle stage %s of cluster %s failed: %w", stage, clusterName, err)
+		}
+		if err := s.Record(ctx, clusterName, stage); err != nil {
+			return err
+		}
+	}
+	return nil
+}
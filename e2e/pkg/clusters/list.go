@@ -0,0 +1,147 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package clusters
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/selection"
+	clusterv1 "open-cluster-management.io/api/cluster/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// defaultListPageSize keeps individual list responses small enough for
+// hubs managing thousands of clusters; callers rarely need to change it.
+const defaultListPageSize = 250
+
+// ClusterSelector composes label and field constraints for cluster
+// listing, replacing hand-assembled selector strings in the suites.
+// The zero value matches everything; methods return the receiver for
+// chaining.
+type ClusterSelector struct {
+	requirements []labels.Requirement
+	fields       []fields.Selector
+	err          error
+}
+
+// NewClusterSelector returns an empty selector.
+func NewClusterSelector() *ClusterSelector {
+	return &ClusterSelector{}
+}
+
+func (s *ClusterSelector) require(key string, op selection.Operator, values ...string) *ClusterSelector {
+	requirement, err := labels.NewRequirement(key, op, values)
+	if err != nil && s.err == nil {
+		s.err = fmt.Errorf("invalid selector term %s %s %v: %w", key, op, values, err)
+	}
+	if err == nil {
+		s.requirements = append(s.requirements, *requirement)
+	}
+	return s
+}
+
+// WithLabel requires the label to have exactly the given value.
+func (s *ClusterSelector) WithLabel(key, value string) *ClusterSelector {
+	return s.require(key, selection.Equals, value)
+}
+
+// WithLabelIn requires the label's value to be one of the given values.
+func (s *ClusterSelector) WithLabelIn(key string, values ...string) *ClusterSelector {
+	return s.require(key, selection.In, values...)
+}
+
+// WithLabelExists requires the label to be present with any value.
+func (s *ClusterSelector) WithLabelExists(key string) *ClusterSelector {
+	return s.require(key, selection.Exists)
+}
+
+// WithoutLabel requires the label to be absent.
+func (s *ClusterSelector) WithoutLabel(key string) *ClusterSelector {
+	return s.require(key, selection.DoesNotExist)
+}
+
+// InClusterSet scopes the selection to one ManagedClusterSet.
+func (s *ClusterSelector) InClusterSet(setName string) *ClusterSelector {
+	return s.WithLabel(clusterSetLabel, setName)
+}
+
+// WithName constrains the selection to a single cluster by name, through
+// a field selector so it composes with label terms.
+func (s *ClusterSelector) WithName(name string) *ClusterSelector {
+	s.fields = append(s.fields, fields.OneTermEqualSelector("metadata.name", name))
+	return s
+}
+
+// listOptions renders the selector for the client.
+func (s *ClusterSelector) listOptions() ([]client.ListOption, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	options := []client.ListOption{
+		client.MatchingLabelsSelector{Selector: labels.NewSelector().Add(s.requirements...)},
+	}
+	if len(s.fields) > 0 {
+		options = append(options, client.MatchingFieldsSelector{Selector: fields.AndSelectors(s.fields...)})
+	}
+	return options, nil
+}
+
+// ForEachManagedCluster streams every matching ManagedCluster through fn,
+// fetching pages of pageSize (0 for the default) with list continuation,
+// so the hub never has to materialize the whole fleet in one response.
+// Returning an error from fn stops the iteration.
+func ForEachManagedCluster(ctx context.Context, hub client.Client, selector *ClusterSelector,
+	pageSize int, fn func(cluster *clusterv1.ManagedCluster) error) error {
+	if selector == nil {
+		selector = NewClusterSelector()
+	}
+	if pageSize <= 0 {
+		pageSize = defaultListPageSize
+	}
+	baseOptions, err := selector.listOptions()
+	if err != nil {
+		return err
+	}
+
+	continueToken := ""
+	for {
+		page := &clusterv1.ManagedClusterList{}
+		options := append([]client.ListOption{}, baseOptions...)
+		options = append(options, client.Limit(int64(pageSize)))
+		if continueToken != "" {
+			options = append(options, client.Continue(continueToken))
+		}
+		if err := hub.List(ctx, page, options...); err != nil {
+			return fmt.Errorf("failed to list managed clusters: %w", err)
+		}
+		for i := range page.Items {
+			if err := fn(&page.Items[i]); err != nil {
+				return err
+			}
+		}
+		continueToken = page.GetContinue()
+		if continueToken == "" {
+			return nil
+		}
+	}
+}
+
+// ListManagedClusters collects every matching cluster. Prefer
+// ForEachManagedCluster when the results are only iterated; this variant
+// exists for callers that need the full slice.
+func ListManagedClusters(ctx context.Context, hub client.Client, selector *ClusterSelector,
+	pageSize int) ([]clusterv1.ManagedCluster, error) {
+	var clusters []clusterv1.ManagedCluster
+	err := ForEachManagedCluster(ctx, hub, selector, pageSize,
+		func(cluster *clusterv1.ManagedCluster) error {
+			clusters = append(clusters, *cluster)
+			return nil
+		})
+	if err != nil {
+		return nil, err
+	}
+	return clusters, nil
+}
@@ -0,0 +1,139 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package clusters
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clusterv1 "open-cluster-management.io/api/cluster/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func clustersScheme(t *testing.T) *runtime.Scheme {
+	t.Helper()
+	scheme := runtime.NewScheme()
+	if err := clusterv1.Install(scheme); err != nil {
+		t.Fatal(err)
+	}
+	return scheme
+}
+
+// renderListOptions applies the selector's options onto one ListOptions
+// struct, the way the client would.
+func renderListOptions(t *testing.T, selector *ClusterSelector) *client.ListOptions {
+	t.Helper()
+	options, err := selector.listOptions()
+	if err != nil {
+		t.Fatalf("listOptions: %v", err)
+	}
+	rendered := &client.ListOptions{}
+	for _, option := range options {
+		option.ApplyToList(rendered)
+	}
+	return rendered
+}
+
+func TestClusterSelectorComposition(t *testing.T) {
+	selector := NewClusterSelector().
+		WithLabel("environment", "production").
+		WithLabelIn("cloud", "aws", "gcp").
+		WithLabelExists("owner").
+		WithoutLabel("quarantined")
+
+	rendered := renderListOptions(t, selector)
+	labelSelector := rendered.LabelSelector.String()
+	want := "cloud in (aws,gcp),environment=production,owner,!quarantined"
+	if labelSelector != want {
+		t.Errorf("label selector = %q, want %q", labelSelector, want)
+	}
+	if rendered.FieldSelector != nil {
+		t.Errorf("unexpected field selector %v", rendered.FieldSelector)
+	}
+}
+
+func TestClusterSelectorClusterSetAndName(t *testing.T) {
+	selector := NewClusterSelector().InClusterSet("dev-clusters").WithName("cluster1")
+	rendered := renderListOptions(t, selector)
+
+	if got, want := rendered.LabelSelector.String(), clusterSetLabel+"=dev-clusters"; got != want {
+		t.Errorf("label selector = %q, want %q", got, want)
+	}
+	if got, want := rendered.FieldSelector.String(), "metadata.name=cluster1"; got != want {
+		t.Errorf("field selector = %q, want %q", got, want)
+	}
+}
+
+func TestClusterSelectorInvalidTerm(t *testing.T) {
+	selector := NewClusterSelector().WithLabel("not a valid key!", "value")
+	if _, err := selector.listOptions(); err == nil {
+		t.Fatal("invalid label key was accepted")
+	}
+	// The zero selector matches everything and renders without error.
+	if rendered := renderListOptions(t, NewClusterSelector()); !rendered.LabelSelector.Empty() {
+		t.Errorf("zero selector = %q, want empty", rendered.LabelSelector)
+	}
+}
+
+func testCluster(name string, labels map[string]string) *clusterv1.ManagedCluster {
+	return &clusterv1.ManagedCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels},
+	}
+}
+
+func TestForEachManagedCluster(t *testing.T) {
+	hub := fake.NewClientBuilder().WithScheme(clustersScheme(t)).WithObjects(
+		testCluster("prod1", map[string]string{"environment": "production"}),
+		testCluster("prod2", map[string]string{"environment": "production"}),
+		testCluster("dev1", map[string]string{"environment": "dev"}),
+	).Build()
+
+	var seen []string
+	err := ForEachManagedCluster(context.Background(), hub,
+		NewClusterSelector().WithLabel("environment", "production"), 1,
+		func(cluster *clusterv1.ManagedCluster) error {
+			seen = append(seen, cluster.Name)
+			return nil
+		})
+	if err != nil {
+		t.Fatalf("ForEachManagedCluster: %v", err)
+	}
+	if len(seen) != 2 {
+		t.Fatalf("visited %v, want the two production clusters", seen)
+	}
+
+	// An error from fn stops the iteration and is returned as-is.
+	stop := errors.New("stop here")
+	visits := 0
+	err = ForEachManagedCluster(context.Background(), hub, nil, 0,
+		func(cluster *clusterv1.ManagedCluster) error {
+			visits++
+			return stop
+		})
+	if !errors.Is(err, stop) {
+		t.Fatalf("fn error not surfaced, got %v", err)
+	}
+	if visits != 1 {
+		t.Errorf("fn ran %d times after erroring, want 1", visits)
+	}
+}
+
+func TestListManagedClusters(t *testing.T) {
+	hub := fake.NewClientBuilder().WithScheme(clustersScheme(t)).WithObjects(
+		testCluster("c1", map[string]string{clusterSetLabel: "set1"}),
+		testCluster("c2", nil),
+	).Build()
+
+	clusters, err := ListManagedClusters(context.Background(), hub,
+		NewClusterSelector().InClusterSet("set1"), 0)
+	if err != nil {
+		t.Fatalf("ListManagedClusters: %v", err)
+	}
+	if len(clusters) != 1 || clusters[0].Name != "c1" {
+		t.Errorf("clusters = %+v, want only c1", clusters)
+	}
+}
@@ -0,0 +1,250 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package clusters
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	hivev1aws "github.com/openshift/hive/apis/hive/v1/aws"
+	hivev1azure "github.com/openshift/hive/apis/hive/v1/azure"
+	hivev1gcp "github.com/openshift/hive/apis/hive/v1/gcp"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	machinev1beta1 "github.com/openshift/api/machine/v1beta1"
+)
+
+const (
+	// machinePoolNameLabel is set by Hive on every spoke MachineSet that
+	// belongs to a MachinePool.
+	machinePoolNameLabel = "hive.openshift.io/machine-pool"
+
+	machinePoolPollInterval = 15 * time.Second
+	machinePoolWaitTimeout  = 15 * time.Minute
+)
+
+// MachinePoolOptions describes an additional MachinePool to create for a
+// Hive-provisioned cluster.
+type MachinePoolOptions struct {
+	// ClusterName is the ClusterDeployment name; the MachinePool is created
+	// in the namespace of the same name.
+	ClusterName string
+	// Name is the pool short name (for example "infra"). The MachinePool
+	// object is named "<ClusterName>-<Name>" following Hive conventions.
+	Name string
+	// Cloud selects the platform block: "aws", "gcp" or "azure".
+	Cloud string
+	// InstanceType is the cloud-specific machine size.
+	InstanceType string
+	// Zones restricts the pool to the given availability zones. When empty,
+	// Hive spreads the pool across all zones of the region.
+	Zones    []string
+	Replicas int64
+}
+
+// poolObjectName returns the MachinePool object name for a pool short name.
+func poolObjectName(clusterName, poolName string) string {
+	return fmt.Sprintf("%s-%s", clusterName, poolName)
+}
+
+// newMachinePool builds the MachinePool object for the options without
+// creating it, so variants (spot pools, for example) can adjust the spec
+// before the first Create.
+func newMachinePool(opts MachinePoolOptions) (*hivev1.MachinePool, error) {
+	pool := &hivev1.MachinePool{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      poolObjectName(opts.ClusterName, opts.Name),
+			Namespace: opts.ClusterName,
+		},
+		Spec: hivev1.MachinePoolSpec{
+			ClusterDeploymentRef: corev1.LocalObjectReference{Name: opts.ClusterName},
+			Name:                 opts.Name,
+			Replicas:             &opts.Replicas,
+		},
+	}
+
+	switch opts.Cloud {
+	case "aws":
+		pool.Spec.Platform.AWS = &hivev1aws.MachinePoolPlatform{
+			InstanceType: opts.InstanceType,
+			Zones:        opts.Zones,
+		}
+	case "gcp":
+		pool.Spec.Platform.GCP = &hivev1gcp.MachinePool{
+			InstanceType: opts.InstanceType,
+			Zones:        opts.Zones,
+		}
+	case "azure":
+		pool.Spec.Platform.Azure = &hivev1azure.MachinePool{
+			InstanceType: opts.InstanceType,
+			Zones:        opts.Zones,
+		}
+	default:
+		return nil, fmt.Errorf("unsupported cloud %q for machine pool %s", opts.Cloud, opts.Name)
+	}
+	return pool, nil
+}
+
+// CreateMachinePool creates an additional MachinePool on the hub and waits
+// until the corresponding MachineSets exist on the spoke with the expected
+// total replica count.
+func CreateMachinePool(ctx context.Context, hub, spoke client.Client, opts MachinePoolOptions) (*hivev1.MachinePool, error) {
+	pool, err := newMachinePool(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := hub.Create(ctx, pool); err != nil {
+		return nil, fmt.Errorf("failed to create machine pool %s/%s: %w", pool.Namespace, pool.Name, err)
+	}
+
+	if err := waitForPoolMachineSets(ctx, spoke, opts.Name, opts.Replicas); err != nil {
+		return nil, err
+	}
+	return pool, nil
+}
+
+// DeleteMachinePool deletes a MachinePool from the hub and waits until its
+// MachineSets are removed from the spoke.
+func DeleteMachinePool(ctx context.Context, hub, spoke client.Client, clusterName, poolName string) error {
+	pool := &hivev1.MachinePool{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      poolObjectName(clusterName, poolName),
+			Namespace: clusterName,
+		},
+	}
+	if err := hub.Delete(ctx, pool); err != nil && !apierrors.IsNotFound(err) {
+		return fmt.Errorf("failed to delete machine pool %s/%s: %w", pool.Namespace, pool.Name, err)
+	}
+
+	return wait.PollUntilContextTimeout(ctx, machinePoolPollInterval, machinePoolWaitTimeout, true,
+		func(ctx context.Context) (bool, error) {
+			sets, err := listPoolMachineSets(ctx, spoke, poolName)
+			if err != nil {
+				return false, err
+			}
+			return len(sets.Items) == 0, nil
+		})
+}
+
+// listPoolMachineSets returns the spoke MachineSets owned by the named pool.
+func listPoolMachineSets(ctx context.Context, spoke client.Client, poolName string) (*machinev1beta1.MachineSetList, error) {
+	sets := &machinev1beta1.MachineSetList{}
+	err := spoke.List(ctx, sets,
+		client.InNamespace("openshift-machine-api"),
+		client.MatchingLabels{machinePoolNameLabel: poolName})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list machine sets for pool %s: %w", poolName, err)
+	}
+	return sets, nil
+}
+
+// waitForPoolMachineSets waits until the spoke MachineSets of a pool exist
+// and their desired replicas sum to the expected total.
+func waitForPoolMachineSets(ctx context.Context, spoke client.Client, poolName string, expected int64) error {
+	return wait.PollUntilContextTimeout(ctx, machinePoolPollInterval, machinePoolWaitTimeout, true,
+		func(ctx context.Context) (bool, error) {
+			sets, err := listPoolMachineSets(ctx, spoke, poolName)
+			if err != nil {
+				return false, err
+			}
+			if len(sets.Items) == 0 {
+				return false, nil
+			}
+			var total int64
+			for _, set := range sets.Items {
+				if set.Spec.Replicas != nil {
+					total += int64(*set.Spec.Replicas)
+				}
+			}
+			return total == expected, nil
+		})
+}
+
+// ScaleMachinePoolTo sets the named pool to an absolute replica target and
+// verifies the spoke MachineSets converge on it.
+func ScaleMachinePoolTo(ctx context.Context, hub, spoke client.Client, clusterName, poolName string, target int64) error {
+	if target < 0 {
+		return fmt.Errorf("machine pool replica target must be non-negative, got %d", target)
+	}
+	pool := &hivev1.MachinePool{}
+	key := client.ObjectKey{Namespace: clusterName, Name: poolObjectName(clusterName, poolName)}
+	if err := hub.Get(ctx, key, pool); err != nil {
+		return fmt.Errorf("failed to get machine pool %s: %w", key, err)
+	}
+	pool.Spec.Replicas = &target
+	if err := hub.Update(ctx, pool); err != nil {
+		return fmt.Errorf("failed to scale machine pool %s to %d: %w", key, target, err)
+	}
+	return waitForPoolMachineSets(ctx, spoke, poolName, target)
+}
+
+// VerifyPoolZoneDistribution asserts that the pool's replicas are balanced
+// across its MachineSets: every per-zone MachineSet carries either
+// total/zones or total/zones+1 desired replicas, matching how Hive spreads
+// an absolute target over availability zones.
+func VerifyPoolZoneDistribution(ctx context.Context, spoke client.Client, poolName string, total int64) error {
+	sets, err := listPoolMachineSets(ctx, spoke, poolName)
+	if err != nil {
+		return err
+	}
+	zones := int64(len(sets.Items))
+	if zones == 0 {
+		return fmt.Errorf("no machine sets found for pool %s", poolName)
+	}
+
+	floor := total / zones
+	remainder := total % zones
+	var got, larger int64
+	for _, set := range sets.Items {
+		if set.Spec.Replicas == nil {
+			return fmt.Errorf("machine set %s of pool %s has no desired replicas", set.Name, poolName)
+		}
+		replicas := int64(*set.Spec.Replicas)
+		if replicas != floor && replicas != floor+1 {
+			return fmt.Errorf("machine set %s of pool %s has %d replicas, expected %d or %d",
+				set.Name, poolName, replicas, floor, floor+1)
+		}
+		if replicas == floor+1 {
+			larger++
+		}
+		got += replicas
+	}
+	if got != total {
+		return fmt.Errorf("pool %s machine sets sum to %d replicas, expected %d", poolName, got, total)
+	}
+	if remainder != 0 && larger != remainder {
+		return fmt.Errorf("pool %s has %d machine sets with %d replicas, expected %d", poolName, larger, floor+1, remainder)
+	}
+	return nil
+}
+
+// MachinePoolScalingCheck scales the named pool by delta replicas and
+// verifies the spoke MachineSets converge on the new total.
+func MachinePoolScalingCheck(ctx context.Context, hub, spoke client.Client, clusterName, poolName string, delta int64) error {
+	pool := &hivev1.MachinePool{}
+	key := client.ObjectKey{Namespace: clusterName, Name: poolObjectName(clusterName, poolName)}
+	if err := hub.Get(ctx, key, pool); err != nil {
+		return fmt.Errorf("failed to get machine pool %s: %w", key, err)
+	}
+	if pool.Spec.Replicas == nil {
+		return fmt.Errorf("machine pool %s has no explicit replicas; cannot scale by delta", key)
+	}
+
+	target := *pool.Spec.Replicas + delta
+	if target < 0 {
+		return fmt.Errorf("scaling machine pool %s by %d would result in negative replicas", key, delta)
+	}
+	pool.Spec.Replicas = &target
+	if err := hub.Update(ctx, pool); err != nil {
+		return fmt.Errorf("failed to scale machine pool %s: %w", key, err)
+	}
+
+	return waitForPoolMachineSets(ctx, spoke, poolName, target)
+}
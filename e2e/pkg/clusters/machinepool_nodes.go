@@ -0,0 +1,126 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package clusters
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const nodePropagationTimeout = 20 * time.Minute
+
+// SetMachinePoolTaints replaces the taints of a MachinePool on the hub and
+// waits until every node of the pool on the spoke carries them.
+func SetMachinePoolTaints(ctx context.Context, hub, spoke client.Client, clusterName, poolName string, taints []corev1.Taint) error {
+	pool := &hivev1.MachinePool{}
+	key := client.ObjectKey{Namespace: clusterName, Name: poolObjectName(clusterName, poolName)}
+	if err := hub.Get(ctx, key, pool); err != nil {
+		return fmt.Errorf("failed to get machine pool %s: %w", key, err)
+	}
+	pool.Spec.Taints = taints
+	if err := hub.Update(ctx, pool); err != nil {
+		return fmt.Errorf("failed to set taints on machine pool %s: %w", key, err)
+	}
+
+	return forEachPoolNode(ctx, spoke, poolName, func(node *corev1.Node) error {
+		for _, want := range taints {
+			if !nodeHasTaint(node, want) {
+				return fmt.Errorf("node %s is missing taint %s=%s:%s", node.Name, want.Key, want.Value, want.Effect)
+			}
+		}
+		return nil
+	})
+}
+
+// SetMachinePoolLabels replaces the node labels of a MachinePool on the hub
+// and waits until every node of the pool on the spoke carries them.
+func SetMachinePoolLabels(ctx context.Context, hub, spoke client.Client, clusterName, poolName string, labels map[string]string) error {
+	pool := &hivev1.MachinePool{}
+	key := client.ObjectKey{Namespace: clusterName, Name: poolObjectName(clusterName, poolName)}
+	if err := hub.Get(ctx, key, pool); err != nil {
+		return fmt.Errorf("failed to get machine pool %s: %w", key, err)
+	}
+	pool.Spec.Labels = labels
+	if err := hub.Update(ctx, pool); err != nil {
+		return fmt.Errorf("failed to set labels on machine pool %s: %w", key, err)
+	}
+
+	return forEachPoolNode(ctx, spoke, poolName, func(node *corev1.Node) error {
+		for k, v := range labels {
+			if node.Labels[k] != v {
+				return fmt.Errorf("node %s has label %s=%q, expected %q", node.Name, k, node.Labels[k], v)
+			}
+		}
+		return nil
+	})
+}
+
+// forEachPoolNode polls until check passes for every spoke node belonging to
+// the pool's MachineSets. New machines only pick up pool changes on
+// replacement, so the timeout accommodates a rolling update.
+func forEachPoolNode(ctx context.Context, spoke client.Client, poolName string, check func(*corev1.Node) error) error {
+	var lastErr error
+	err := wait.PollUntilContextTimeout(ctx, machinePoolPollInterval, nodePropagationTimeout, true,
+		func(ctx context.Context) (bool, error) {
+			nodes, err := listPoolNodes(ctx, spoke, poolName)
+			if err != nil {
+				return false, err
+			}
+			if len(nodes) == 0 {
+				lastErr = fmt.Errorf("no nodes found for pool %s", poolName)
+				return false, nil
+			}
+			for i := range nodes {
+				if err := check(&nodes[i]); err != nil {
+					lastErr = err
+					return false, nil
+				}
+			}
+			return true, nil
+		})
+	if err != nil && lastErr != nil {
+		return fmt.Errorf("%w: %v", err, lastErr)
+	}
+	return err
+}
+
+// listPoolNodes returns the spoke nodes created by the pool's MachineSets,
+// matched through the machine-api machineset label stamped on each node's
+// machine annotation counterpart.
+func listPoolNodes(ctx context.Context, spoke client.Client, poolName string) ([]corev1.Node, error) {
+	sets, err := listPoolMachineSets(ctx, spoke, poolName)
+	if err != nil {
+		return nil, err
+	}
+	setNames := map[string]bool{}
+	for _, set := range sets.Items {
+		setNames[set.Name] = true
+	}
+
+	nodes := &corev1.NodeList{}
+	if err := spoke.List(ctx, nodes); err != nil {
+		return nil, fmt.Errorf("failed to list spoke nodes: %w", err)
+	}
+	var matched []corev1.Node
+	for _, node := range nodes.Items {
+		if setNames[node.Annotations["machine.openshift.io/machine-set"]] {
+			matched = append(matched, node)
+		}
+	}
+	return matched, nil
+}
+
+func nodeHasTaint(node *corev1.Node, want corev1.Taint) bool {
+	for _, taint := range node.Spec.Taints {
+		if taint.Key == want.Key && taint.Value == want.Value && taint.Effect == want.Effect {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,122 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package clusters
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	hivev1aws "github.com/openshift/hive/apis/hive/v1/aws"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// SpotMachinePoolOptions describes a cost-optimized MachinePool backed by
+// AWS spot instances. Hive's MachinePool API only models interruptible
+// capacity for AWS; GCP preemptible and Azure spot VMs have no pool-level
+// fields.
+type SpotMachinePoolOptions struct {
+	MachinePoolOptions
+	// MaxPrice caps the hourly spot price. Empty means the on-demand
+	// price.
+	MaxPrice string
+}
+
+// awsSpotMarker is the field that marks spot capacity in the machine-api
+// providerSpec of the resulting MachineSets.
+const awsSpotMarker = "spotMarketOptions"
+
+// CreateSpotMachinePool creates a MachinePool that requests spot capacity
+// and verifies the spot configuration lands on the spoke MachineSets'
+// provider specs. The spot platform fields are set on the spec before the
+// Create call: patching them onto an existing pool would first provision
+// on-demand capacity and pay for it until the patch reconciles.
+func CreateSpotMachinePool(ctx context.Context, hub, spoke client.Client, opts SpotMachinePoolOptions) (*hivev1.MachinePool, error) {
+	if opts.Cloud != "aws" {
+		return nil, fmt.Errorf("cloud %q does not support spot machine pools", opts.Cloud)
+	}
+
+	pool, err := newMachinePool(opts.MachinePoolOptions)
+	if err != nil {
+		return nil, err
+	}
+	applySpotOptions(pool, opts)
+
+	if err := hub.Create(ctx, pool); err != nil {
+		return nil, fmt.Errorf("failed to create spot machine pool %s/%s: %w", pool.Namespace, pool.Name, err)
+	}
+
+	if err := waitForPoolMachineSets(ctx, spoke, opts.Name, opts.Replicas); err != nil {
+		return nil, err
+	}
+	if err := VerifySpotConfiguration(ctx, spoke, opts.Name, opts.Cloud); err != nil {
+		return nil, err
+	}
+	return pool, nil
+}
+
+// applySpotOptions sets the spot-capacity fields on the pool spec. The
+// pool must be an AWS pool.
+func applySpotOptions(pool *hivev1.MachinePool, opts SpotMachinePoolOptions) {
+	pool.Spec.Platform.AWS.SpotMarketOptions = &hivev1aws.SpotMarketOptions{}
+	if opts.MaxPrice != "" {
+		pool.Spec.Platform.AWS.SpotMarketOptions.MaxPrice = &opts.MaxPrice
+	}
+}
+
+// VerifySpotConfiguration waits until every spoke MachineSet of the pool
+// carries the spot marker in its providerSpec. It polls because Hive and
+// the machine-api operator sync the MachineSets asynchronously; a one-shot
+// check races the reconcile.
+func VerifySpotConfiguration(ctx context.Context, spoke client.Client, poolName, cloud string) error {
+	if cloud != "aws" {
+		return fmt.Errorf("cloud %q does not support spot machine pools", cloud)
+	}
+	key := awsSpotMarker
+
+	var lastErr error
+	err := wait.PollUntilContextTimeout(ctx, machinePoolPollInterval, machinePoolWaitTimeout, true,
+		func(ctx context.Context) (bool, error) {
+			if lastErr = checkSpotConfiguration(ctx, spoke, poolName, key); lastErr != nil {
+				return false, nil
+			}
+			return true, nil
+		})
+	if err != nil {
+		if lastErr != nil {
+			return fmt.Errorf("spot configuration of pool %s never converged: %w: %v", poolName, err, lastErr)
+		}
+		return fmt.Errorf("spot configuration of pool %s never converged: %w", poolName, err)
+	}
+	return nil
+}
+
+// checkSpotConfiguration is the one-shot form of VerifySpotConfiguration:
+// it asserts the marker on the current MachineSets without waiting.
+func checkSpotConfiguration(ctx context.Context, spoke client.Client, poolName, key string) error {
+	sets, err := listPoolMachineSets(ctx, spoke, poolName)
+	if err != nil {
+		return err
+	}
+	if len(sets.Items) == 0 {
+		return fmt.Errorf("no machine sets found for spot pool %s", poolName)
+	}
+
+	for _, set := range sets.Items {
+		raw := set.Spec.Template.Spec.ProviderSpec.Value
+		if raw == nil {
+			return fmt.Errorf("machine set %s of pool %s has no providerSpec", set.Name, poolName)
+		}
+		spec := map[string]interface{}{}
+		if err := json.Unmarshal(raw.Raw, &spec); err != nil {
+			return fmt.Errorf("failed to decode providerSpec of machine set %s: %w", set.Name, err)
+		}
+		value, found := spec[key]
+		if !found || value == nil || value == false {
+			return fmt.Errorf("machine set %s of pool %s is missing spot marker %q in providerSpec", set.Name, poolName, key)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,112 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package clusters
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	machinev1beta1 "github.com/openshift/api/machine/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func spotTestOptions(cloud string) SpotMachinePoolOptions {
+	return SpotMachinePoolOptions{
+		MachinePoolOptions: MachinePoolOptions{
+			ClusterName:  "cluster1",
+			Name:         "spot",
+			Cloud:        cloud,
+			InstanceType: "m5.xlarge",
+			Replicas:     3,
+		},
+		MaxPrice: "0.50",
+	}
+}
+
+// TestSpotOptionsSetBeforeCreate pins the fix for spot pools briefly
+// provisioning on-demand capacity: the spot fields must be present on the
+// spec handed to Create, not patched in afterwards.
+func TestSpotOptionsSetBeforeCreate(t *testing.T) {
+	opts := spotTestOptions("aws")
+	pool, err := newMachinePool(opts.MachinePoolOptions)
+	if err != nil {
+		t.Fatalf("newMachinePool: %v", err)
+	}
+	applySpotOptions(pool, opts)
+
+	spot := pool.Spec.Platform.AWS.SpotMarketOptions
+	if spot == nil || spot.MaxPrice == nil || *spot.MaxPrice != "0.50" {
+		t.Errorf("spot options = %+v, want max price 0.50", spot)
+	}
+}
+
+func TestCreateSpotMachinePoolRejectsUnsupportedCloud(t *testing.T) {
+	// Hive has no pool-level preemptible support for GCP.
+	_, err := CreateSpotMachinePool(context.Background(), nil, nil, spotTestOptions("gcp"))
+	if err == nil || !strings.Contains(err.Error(), "does not support spot") {
+		t.Fatalf("unsupported cloud error = %v", err)
+	}
+}
+
+func spotMachineSet(name, pool string, providerSpec string) *machinev1beta1.MachineSet {
+	set := &machinev1beta1.MachineSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "openshift-machine-api",
+			Labels:    map[string]string{machinePoolNameLabel: pool},
+		},
+	}
+	if providerSpec != "" {
+		set.Spec.Template.Spec.ProviderSpec.Value = &runtime.RawExtension{Raw: []byte(providerSpec)}
+	}
+	return set
+}
+
+func spotTestSpoke(t *testing.T, objects ...client.Object) client.Client {
+	t.Helper()
+	scheme := runtime.NewScheme()
+	if err := machinev1beta1.Install(scheme); err != nil {
+		t.Fatal(err)
+	}
+	return fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).Build()
+}
+
+func TestCheckSpotConfiguration(t *testing.T) {
+	spoke := spotTestSpoke(t,
+		spotMachineSet("cluster1-spot-a", "spot", `{"spotMarketOptions":{"maxPrice":"0.50"}}`),
+		spotMachineSet("cluster1-spot-b", "spot", `{"spotMarketOptions":{}}`),
+	)
+	if err := checkSpotConfiguration(context.Background(), spoke, "spot", "spotMarketOptions"); err != nil {
+		t.Fatalf("checkSpotConfiguration: %v", err)
+	}
+}
+
+func TestCheckSpotConfigurationMissingMarker(t *testing.T) {
+	spoke := spotTestSpoke(t,
+		spotMachineSet("cluster1-spot-a", "spot", `{"spotMarketOptions":{}}`),
+		spotMachineSet("cluster1-spot-b", "spot", `{"instanceType":"m5.xlarge"}`),
+	)
+	err := checkSpotConfiguration(context.Background(), spoke, "spot", "spotMarketOptions")
+	if err == nil || !strings.Contains(err.Error(), "missing spot marker") {
+		t.Fatalf("error = %v, want missing spot marker", err)
+	}
+
+	// A marker that is explicitly false is not good enough.
+	spoke = spotTestSpoke(t, spotMachineSet("cluster1-spot-a", "spot", `{"spotMarketOptions":false}`))
+	err = checkSpotConfiguration(context.Background(), spoke, "spot", "spotMarketOptions")
+	if err == nil || !strings.Contains(err.Error(), "missing spot marker") {
+		t.Fatalf("error = %v, want missing spot marker", err)
+	}
+}
+
+func TestCheckSpotConfigurationNoMachineSets(t *testing.T) {
+	spoke := spotTestSpoke(t)
+	err := checkSpotConfiguration(context.Background(), spoke, "spot", "spotMarketOptions")
+	if err == nil || !strings.Contains(err.Error(), "no machine sets") {
+		t.Fatalf("error = %v, want no machine sets", err)
+	}
+}
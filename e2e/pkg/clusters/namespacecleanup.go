@@ -0,0 +1,129 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package clusters
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/dynamic"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const namespaceCleanupPollInterval = 10 * time.Second
+
+// BlockingResource describes a resource whose finalizers are holding a
+// terminating namespace open.
+type BlockingResource struct {
+	GroupResource string
+	Name          string
+	Finalizers    []string
+}
+
+func (r BlockingResource) String() string {
+	return fmt.Sprintf("%s %s (finalizers: %s)", r.GroupResource, r.Name, strings.Join(r.Finalizers, ", "))
+}
+
+// VerifyNamespaceCleanup waits for the namespace to fully terminate. On
+// timeout it enumerates the resources still present with finalizers and
+// returns them in the error, turning "namespace stuck terminating" into
+// an actionable report of what is holding it. Detach and destroy flows
+// call this on the cluster namespace.
+func VerifyNamespaceCleanup(ctx context.Context, hub client.Client,
+	discoveryClient discovery.DiscoveryInterface, dynamicClient dynamic.Interface,
+	namespace string, timeout time.Duration) error {
+	err := wait.PollUntilContextTimeout(ctx, namespaceCleanupPollInterval, timeout, true,
+		func(ctx context.Context) (bool, error) {
+			ns := &corev1.Namespace{}
+			if err := hub.Get(ctx, client.ObjectKey{Name: namespace}, ns); err != nil {
+				if errors.IsNotFound(err) {
+					return true, nil
+				}
+				return false, err
+			}
+			return false, nil
+		})
+	if err == nil {
+		return nil
+	}
+
+	blocking, listErr := listBlockingResources(ctx, discoveryClient, dynamicClient, namespace)
+	if listErr != nil {
+		return fmt.Errorf("namespace %s was not cleaned up within %s (and listing blockers failed: %v): %w",
+			namespace, timeout, listErr, err)
+	}
+	if len(blocking) == 0 {
+		return fmt.Errorf("namespace %s was not cleaned up within %s, but no resources with finalizers remain: %w",
+			namespace, timeout, err)
+	}
+	lines := make([]string, 0, len(blocking))
+	for _, resource := range blocking {
+		lines = append(lines, resource.String())
+	}
+	return fmt.Errorf("namespace %s was not cleaned up within %s; blocked by:\n  %s",
+		namespace, timeout, strings.Join(lines, "\n  "))
+}
+
+// listBlockingResources walks every namespaced, listable resource type
+// and collects the objects that still carry finalizers.
+func listBlockingResources(ctx context.Context, discoveryClient discovery.DiscoveryInterface,
+	dynamicClient dynamic.Interface, namespace string) ([]BlockingResource, error) {
+	resourceLists, err := discoveryClient.ServerPreferredNamespacedResources()
+	if err != nil && len(resourceLists) == 0 {
+		// Partial discovery failures (stale aggregated APIs) still return
+		// the reachable groups; only a total failure is fatal.
+		return nil, fmt.Errorf("failed to discover namespaced resources: %w", err)
+	}
+
+	var blocking []BlockingResource
+	for _, resourceList := range resourceLists {
+		groupVersion, err := schema.ParseGroupVersion(resourceList.GroupVersion)
+		if err != nil {
+			continue
+		}
+		for _, resource := range resourceList.APIResources {
+			if !supportsList(resource) {
+				continue
+			}
+			gvr := groupVersion.WithResource(resource.Name)
+			objects, err := dynamicClient.Resource(gvr).Namespace(namespace).List(ctx, metav1.ListOptions{})
+			if err != nil {
+				// Resources whose API is gone mid-teardown are expected;
+				// they cannot be what blocks the namespace.
+				continue
+			}
+			for _, object := range objects.Items {
+				if len(object.GetFinalizers()) == 0 {
+					continue
+				}
+				groupResource := resource.Name
+				if groupVersion.Group != "" {
+					groupResource += "." + groupVersion.Group
+				}
+				blocking = append(blocking, BlockingResource{
+					GroupResource: groupResource,
+					Name:          object.GetName(),
+					Finalizers:    object.GetFinalizers(),
+				})
+			}
+		}
+	}
+	return blocking, nil
+}
+
+func supportsList(resource metav1.APIResource) bool {
+	for _, verb := range resource.Verbs {
+		if verb == "list" {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,72 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package clusters
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"golang.org/x/sync/errgroup"
+	clusterv1 "open-cluster-management.io/api/cluster/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// defaultImportConcurrency bounds simultaneous imports; the hub handles
+// registrations fine in parallel, but unbounded fan-out of 8+ imports
+// makes the import controller's work queue the bottleneck again.
+const defaultImportConcurrency = 4
+
+// ImportResult reports one cluster's outcome of a parallel import.
+type ImportResult struct {
+	ClusterName string
+	Cluster     *clusterv1.ManagedCluster
+	Err         error
+}
+
+// ImportClustersParallel imports all clusters concurrently, each with its
+// own wait loop, instead of serially — serial import of a large fleet
+// dominates suite wall time. Every import runs to completion regardless
+// of the others' failures; the aggregated error lists every cluster that
+// failed, and per-cluster detail is in the returned results.
+func ImportClustersParallel(ctx context.Context, hub client.Client, optionSets []ImportOptions,
+	concurrency int) ([]ImportResult, error) {
+	if concurrency <= 0 {
+		concurrency = defaultImportConcurrency
+	}
+
+	results := make([]ImportResult, len(optionSets))
+	var mu sync.Mutex
+	// A plain group, not errgroup.WithContext: the workers record their
+	// errors instead of returning them, so one cluster's failure never
+	// cancels the siblings' wait loops. The caller's ctx is passed through
+	// untouched — cancelling it (suite shutdown, spec deadline) stops every
+	// in-flight import.
+	var group errgroup.Group
+	group.SetLimit(concurrency)
+
+	for i := range optionSets {
+		opts := optionSets[i]
+		index := i
+		group.Go(func() error {
+			cluster, err := ImportCluster(ctx, hub, opts)
+			mu.Lock()
+			results[index] = ImportResult{ClusterName: opts.ClusterName, Cluster: cluster, Err: err}
+			mu.Unlock()
+			return nil
+		})
+	}
+	// The workers always return nil; errors are collected in results.
+	_ = group.Wait()
+
+	var failed []string
+	for _, result := range results {
+		if result.Err != nil {
+			failed = append(failed, result.ClusterName)
+		}
+	}
+	if len(failed) > 0 {
+		return results, fmt.Errorf("%d of %d cluster imports failed: %v", len(failed), len(optionSets), failed)
+	}
+	return results, nil
+}
@@ -0,0 +1,127 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package clusters
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clusterv1 "open-cluster-management.io/api/cluster/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func importScheme(t *testing.T) *runtime.Scheme {
+	t.Helper()
+	scheme := runtime.NewScheme()
+	if err := clusterv1.Install(scheme); err != nil {
+		t.Fatal(err)
+	}
+	if err := corev1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	return scheme
+}
+
+func availableCluster(name string) *clusterv1.ManagedCluster {
+	return &clusterv1.ManagedCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec:       clusterv1.ManagedClusterSpec{HubAcceptsClient: true},
+		Status: clusterv1.ManagedClusterStatus{
+			Conditions: []metav1.Condition{{
+				Type:               clusterv1.ManagedClusterConditionAvailable,
+				Status:             metav1.ConditionTrue,
+				Reason:             "ManagedClusterAvailable",
+				LastTransitionTime: metav1.Now(),
+			}},
+		},
+	}
+}
+
+func TestImportClustersParallelAllAvailable(t *testing.T) {
+	hub := fake.NewClientBuilder().WithScheme(importScheme(t)).WithObjects(
+		availableCluster("c1"),
+		availableCluster("c2"),
+		availableCluster("c3"),
+	).Build()
+
+	optionSets := []ImportOptions{
+		{ClusterName: "c1"}, {ClusterName: "c2"}, {ClusterName: "c3"},
+	}
+	results, err := ImportClustersParallel(context.Background(), hub, optionSets, 2)
+	if err != nil {
+		t.Fatalf("ImportClustersParallel: %v", err)
+	}
+	if len(results) != 3 {
+		t.Fatalf("got %d results, want 3", len(results))
+	}
+	for i, result := range results {
+		if result.ClusterName != optionSets[i].ClusterName {
+			t.Errorf("result %d is for %q, want %q (order must match the input)",
+				i, result.ClusterName, optionSets[i].ClusterName)
+		}
+		if result.Err != nil || result.Cluster == nil {
+			t.Errorf("result %d = %+v, want a cluster and no error", i, result)
+		}
+	}
+}
+
+// TestImportClustersParallelCancellation pins the regression where imports
+// were detached from the caller's context: cancelling ctx must stop every
+// in-flight wait loop instead of letting them poll to their full timeout.
+func TestImportClustersParallelCancellation(t *testing.T) {
+	hub := fake.NewClientBuilder().WithScheme(importScheme(t)).Build()
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	start := time.Now()
+	results, err := ImportClustersParallel(ctx, hub, []ImportOptions{
+		{ClusterName: "c1"}, {ClusterName: "c2"},
+	}, 2)
+	if elapsed := time.Since(start); elapsed > 30*time.Second {
+		t.Fatalf("cancelled imports still took %s", elapsed)
+	}
+	if err == nil {
+		t.Fatal("cancelled imports reported success")
+	}
+	if !strings.Contains(err.Error(), "2 of 2") {
+		t.Errorf("aggregated error = %v, want both clusters reported failed", err)
+	}
+	for _, result := range results {
+		if !errors.Is(result.Err, context.Canceled) {
+			t.Errorf("result for %s = %v, want context.Canceled in the chain", result.ClusterName, result.Err)
+		}
+	}
+}
+
+func TestImportClustersParallelPartialFailure(t *testing.T) {
+	// c1 is already available; c2 is mid-detach, which ImportCluster
+	// refuses immediately.
+	detaching := availableCluster("c2")
+	detaching.Status.Conditions = nil
+	now := metav1.Now()
+	detaching.DeletionTimestamp = &now
+	detaching.Finalizers = []string{"cluster.open-cluster-management.io/api-resource-cleanup"}
+
+	hub := fake.NewClientBuilder().WithScheme(importScheme(t)).WithObjects(
+		availableCluster("c1"), detaching,
+	).Build()
+
+	results, err := ImportClustersParallel(context.Background(), hub, []ImportOptions{
+		{ClusterName: "c1"}, {ClusterName: "c2"},
+	}, 0)
+	if err == nil || !strings.Contains(err.Error(), "1 of 2") {
+		t.Fatalf("aggregated error = %v, want one failure out of two", err)
+	}
+	if results[0].Err != nil {
+		t.Errorf("c1 failed: %v", results[0].Err)
+	}
+	if results[1].Err == nil {
+		t.Error("c2 import of a detaching cluster succeeded")
+	}
+}
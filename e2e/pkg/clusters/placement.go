@@ -0,0 +1,128 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package clusters
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	clusterv1beta1 "open-cluster-management.io/api/cluster/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// placementLabel links PlacementDecisions back to their Placement.
+const placementLabel = "cluster.open-cluster-management.io/placement"
+
+// PlacementOptions describes a Placement to create. The namespace must
+// already have a ManagedClusterSetBinding for every set in ClusterSets.
+type PlacementOptions struct {
+	Name      string
+	Namespace string
+	// ClusterSets restricts scheduling to the named sets. Empty means all
+	// sets bound into the namespace.
+	ClusterSets []string
+	// NumberOfClusters limits the decision size. Nil selects all matches.
+	NumberOfClusters *int32
+	Predicates       []clusterv1beta1.ClusterPredicate
+	Tolerations      []clusterv1beta1.Toleration
+	SpreadPolicy     *clusterv1beta1.SpreadPolicy
+}
+
+// CreatePlacement creates a Placement and waits for its Satisfied condition.
+func CreatePlacement(ctx context.Context, hub client.Client, opts PlacementOptions) (*clusterv1beta1.Placement, error) {
+	placement := &clusterv1beta1.Placement{
+		ObjectMeta: metav1.ObjectMeta{Name: opts.Name, Namespace: opts.Namespace},
+		Spec: clusterv1beta1.PlacementSpec{
+			ClusterSets:      opts.ClusterSets,
+			NumberOfClusters: opts.NumberOfClusters,
+			Predicates:       opts.Predicates,
+			Tolerations:      opts.Tolerations,
+		},
+	}
+	if opts.SpreadPolicy != nil {
+		placement.Spec.SpreadPolicy = *opts.SpreadPolicy
+	}
+	if err := hub.Create(ctx, placement); err != nil && !apierrors.IsAlreadyExists(err) {
+		return nil, fmt.Errorf("failed to create placement %s/%s: %w", opts.Namespace, opts.Name, err)
+	}
+
+	err := wait.PollUntilContextTimeout(ctx, clusterSetPollInterval, clusterSetWaitTimeout, true,
+		func(ctx context.Context) (bool, error) {
+			if err := hub.Get(ctx, client.ObjectKey{Namespace: opts.Namespace, Name: opts.Name}, placement); err != nil {
+				return false, err
+			}
+			return meta.IsStatusConditionTrue(placement.Status.Conditions, clusterv1beta1.PlacementConditionSatisfied), nil
+		})
+	if err != nil {
+		return nil, fmt.Errorf("placement %s/%s was not satisfied: %w", opts.Namespace, opts.Name, err)
+	}
+	return placement, nil
+}
+
+// DeletePlacement removes a Placement; its PlacementDecisions are garbage
+// collected by the scheduler.
+func DeletePlacement(ctx context.Context, hub client.Client, namespace, name string) error {
+	placement := &clusterv1beta1.Placement{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+	}
+	if err := hub.Delete(ctx, placement); err != nil && !apierrors.IsNotFound(err) {
+		return fmt.Errorf("failed to delete placement %s/%s: %w", namespace, name, err)
+	}
+	return nil
+}
+
+// GetPlacementDecisionClusters returns the sorted cluster names selected by
+// a Placement, aggregated over all of its PlacementDecision slices.
+func GetPlacementDecisionClusters(ctx context.Context, hub client.Client, namespace, name string) ([]string, error) {
+	decisions := &clusterv1beta1.PlacementDecisionList{}
+	err := hub.List(ctx, decisions,
+		client.InNamespace(namespace),
+		client.MatchingLabels{placementLabel: name})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list placement decisions for %s/%s: %w", namespace, name, err)
+	}
+
+	var names []string
+	for _, decision := range decisions.Items {
+		for _, choice := range decision.Status.Decisions {
+			names = append(names, choice.ClusterName)
+		}
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// VerifyPlacementDecision waits until the Placement's decisions match
+// exactly the expected cluster names.
+func VerifyPlacementDecision(ctx context.Context, hub client.Client, namespace, name string, expected []string) error {
+	want := append([]string(nil), expected...)
+	sort.Strings(want)
+
+	var got []string
+	err := wait.PollUntilContextTimeout(ctx, clusterSetPollInterval, clusterSetWaitTimeout, true,
+		func(ctx context.Context) (bool, error) {
+			var err error
+			got, err = GetPlacementDecisionClusters(ctx, hub, namespace, name)
+			if err != nil {
+				return false, err
+			}
+			if len(got) != len(want) {
+				return false, nil
+			}
+			for i := range got {
+				if got[i] != want[i] {
+					return false, nil
+				}
+			}
+			return true, nil
+		})
+	if err != nil {
+		return fmt.Errorf("placement %s/%s decided %v, expected %v: %w", namespace, name, got, want, err)
+	}
+	return nil
+}
@@ -0,0 +1,98 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package clusters
+
+import (
+	"context"
+	"fmt"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	hivev1aws "github.com/openshift/hive/apis/hive/v1/aws"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+)
+
+// CreatePrivateLinkCluster provisions an AWS cluster with Internal publish
+// strategy and Hive PrivateLink enabled, then verifies the hub can reach the
+// cluster API through the PrivateLink endpoint.
+//
+// The Hive AWSPrivateLink controller must already be configured with
+// endpoint VPC inventory for the region; that is environment setup, not a
+// per-test concern.
+func CreatePrivateLinkCluster(ctx context.Context, hub client.Client, opts CreateClusterOptions) (*hivev1.ClusterDeployment, error) {
+	if opts.Cloud != "aws" {
+		return nil, fmt.Errorf("private link clusters are AWS-only, got cloud %q", opts.Cloud)
+	}
+
+	namespace := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: opts.Name}}
+	if err := hub.Create(ctx, namespace); err != nil && !apierrors.IsAlreadyExists(err) {
+		return nil, fmt.Errorf("failed to create namespace for cluster %s: %w", opts.Name, err)
+	}
+
+	config := baseInstallConfig(opts)
+	applyInstallConfigOverrides(config, opts.Cloud, opts.Overrides)
+	config["publish"] = "Internal"
+	raw, err := yaml.Marshal(config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to render install-config for cluster %s: %w", opts.Name, err)
+	}
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      installConfigSecretName(opts.Name),
+			Namespace: opts.Name,
+		},
+		Data: map[string][]byte{"install-config.yaml": raw},
+	}
+	if err := hub.Create(ctx, secret); err != nil && !apierrors.IsAlreadyExists(err) {
+		return nil, fmt.Errorf("failed to create install-config secret for cluster %s: %w", opts.Name, err)
+	}
+
+	deployment, err := newClusterDeployment(opts)
+	if err != nil {
+		return nil, err
+	}
+	deployment.Spec.Platform.AWS.PrivateLink = &hivev1aws.PrivateLinkAccess{Enabled: true}
+	if err := hub.Create(ctx, deployment); err != nil {
+		return nil, fmt.Errorf("failed to create cluster deployment %s: %w", opts.Name, err)
+	}
+
+	if err := WaitForClusterDeploymentInstalled(ctx, hub, opts.Name); err != nil {
+		return nil, err
+	}
+	if err := VerifyPrivateLinkAccess(ctx, hub, opts.Name); err != nil {
+		return nil, err
+	}
+	return deployment, nil
+}
+
+// VerifyPrivateLinkAccess asserts that Hive reports the PrivateLink setup
+// ready and that the hub can reach the private API, observed through the
+// ClusterDeployment's Hibernating/Unreachable conditions staying healthy.
+func VerifyPrivateLinkAccess(ctx context.Context, hub client.Client, clusterName string) error {
+	deployment := &hivev1.ClusterDeployment{}
+	return wait.PollUntilContextTimeout(ctx, createClusterPollInterval, clusterSetWaitTimeout, true,
+		func(ctx context.Context) (bool, error) {
+			if err := hub.Get(ctx, client.ObjectKey{Namespace: clusterName, Name: clusterName}, deployment); err != nil {
+				return false, err
+			}
+			ready := false
+			for _, condition := range deployment.Status.Conditions {
+				switch condition.Type {
+				case hivev1.AWSPrivateLinkReadyClusterDeploymentCondition:
+					if condition.Status != corev1.ConditionTrue {
+						return false, nil
+					}
+					ready = true
+				case hivev1.UnreachableCondition:
+					if condition.Status == corev1.ConditionTrue {
+						return false, fmt.Errorf("cluster %s unreachable over private link: %s", clusterName, condition.Message)
+					}
+				}
+			}
+			return ready, nil
+		})
+}
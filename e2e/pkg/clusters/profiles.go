@@ -0,0 +1,153 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package clusters
+
+import (
+	"context"
+	"fmt"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Profile mutates the rendered install-config to express a well-known
+// cluster topology or platform variant. Profiles compose left to right, so
+// later profiles win on conflicting fields.
+type Profile func(config map[string]interface{})
+
+// CreateClusterWithProfile provisions a cluster with one or more profiles
+// applied on top of the structured overrides.
+func CreateClusterWithProfile(ctx context.Context, hub client.Client, opts CreateClusterOptions, profiles ...Profile) (*hivev1.ClusterDeployment, error) {
+	return createClusterWithConfig(ctx, hub, opts, func(config map[string]interface{}) {
+		for _, profile := range profiles {
+			profile(config)
+		}
+	})
+}
+
+// SingleNode shapes the install-config for single-node OpenShift: one
+// control plane replica, no workers. Only supported on platforms where the
+// installer allows SNO (aws, gcp, azure and none).
+func SingleNode() Profile {
+	return func(config map[string]interface{}) {
+		config["controlPlane"].(map[string]interface{})["replicas"] = int64(1)
+		for _, pool := range config["compute"].([]interface{}) {
+			pool.(map[string]interface{})["replicas"] = int64(0)
+		}
+	}
+}
+
+// CreateSNOCluster provisions a single-node cluster and imports it,
+// returning once it is managed and available. SNO is the common profile for
+// edge scenarios, so creation and import are offered as one step.
+func CreateSNOCluster(ctx context.Context, hub client.Client, opts CreateClusterOptions) (*hivev1.ClusterDeployment, error) {
+	deployment, err := CreateClusterWithProfile(ctx, hub, opts, SingleNode())
+	if err != nil {
+		return nil, err
+	}
+	kubeconfig, err := adminKubeconfigBytes(ctx, hub, opts.Name)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := ImportCluster(ctx, hub, ImportOptions{ClusterName: opts.Name, Kubeconfig: kubeconfig}); err != nil {
+		return nil, err
+	}
+	return deployment, nil
+}
+
+// Compact shapes the install-config for a three-node compact cluster:
+// three control plane replicas, no dedicated workers. The control plane
+// nodes become schedulable automatically when the compute pool is empty.
+func Compact() Profile {
+	return func(config map[string]interface{}) {
+		config["controlPlane"].(map[string]interface{})["replicas"] = int64(3)
+		for _, pool := range config["compute"].([]interface{}) {
+			pool.(map[string]interface{})["replicas"] = int64(0)
+		}
+	}
+}
+
+// VerifyCompactTopology asserts on the spoke that the cluster runs with
+// exactly three nodes, all of them both control plane and worker.
+func VerifyCompactTopology(ctx context.Context, spoke client.Client) error {
+	nodes := &corev1.NodeList{}
+	if err := spoke.List(ctx, nodes); err != nil {
+		return fmt.Errorf("failed to list spoke nodes: %w", err)
+	}
+	if len(nodes.Items) != 3 {
+		return fmt.Errorf("compact cluster has %d nodes, expected 3", len(nodes.Items))
+	}
+	for _, node := range nodes.Items {
+		if _, ok := node.Labels["node-role.kubernetes.io/master"]; !ok {
+			return fmt.Errorf("node %s of compact cluster is not a control plane node", node.Name)
+		}
+		if _, ok := node.Labels["node-role.kubernetes.io/worker"]; !ok {
+			return fmt.Errorf("control plane node %s of compact cluster is not schedulable as worker", node.Name)
+		}
+	}
+	return nil
+}
+
+// ClusterNetworkEntry is one pod network block with its host prefix.
+type ClusterNetworkEntry struct {
+	CIDR       string
+	HostPrefix int64
+}
+
+// NetworkingConfig describes the full networking section of an
+// install-config, for scenarios beyond the single-CIDR overrides.
+type NetworkingConfig struct {
+	// NetworkType selects the CNI plugin, for example OVNKubernetes.
+	NetworkType     string
+	ClusterNetworks []ClusterNetworkEntry
+	ServiceNetworks []string
+	MachineNetworks []string
+}
+
+// Networking replaces the networking section of the install-config. Zero
+// fields keep the defaults of the base config.
+func Networking(networking NetworkingConfig) Profile {
+	return func(config map[string]interface{}) {
+		section := config["networking"].(map[string]interface{})
+		if networking.NetworkType != "" {
+			section["networkType"] = networking.NetworkType
+		}
+		if len(networking.ClusterNetworks) > 0 {
+			entries := make([]interface{}, 0, len(networking.ClusterNetworks))
+			for _, entry := range networking.ClusterNetworks {
+				entries = append(entries, map[string]interface{}{
+					"cidr":       entry.CIDR,
+					"hostPrefix": entry.HostPrefix,
+				})
+			}
+			section["clusterNetwork"] = entries
+		}
+		if len(networking.ServiceNetworks) > 0 {
+			entries := make([]interface{}, 0, len(networking.ServiceNetworks))
+			for _, cidr := range networking.ServiceNetworks {
+				entries = append(entries, cidr)
+			}
+			section["serviceNetwork"] = entries
+		}
+		if len(networking.MachineNetworks) > 0 {
+			entries := make([]interface{}, 0, len(networking.MachineNetworks))
+			for _, cidr := range networking.MachineNetworks {
+				entries = append(entries, map[string]interface{}{"cidr": cidr})
+			}
+			section["machineNetwork"] = entries
+		}
+	}
+}
+
+// ARM64 switches control plane and workers to the arm64 architecture. The
+// referenced ClusterImageSet must point at an arm64 or multi-arch release
+// payload; the profile only adjusts the machine architecture.
+func ARM64() Profile {
+	return func(config map[string]interface{}) {
+		config["controlPlane"].(map[string]interface{})["architecture"] = "arm64"
+		for _, pool := range config["compute"].([]interface{}) {
+			pool.(map[string]interface{})["architecture"] = "arm64"
+		}
+	}
+}
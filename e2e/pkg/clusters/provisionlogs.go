@@ -0,0 +1,90 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package clusters
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// provisionPodLabel identifies Hive provision pods in the cluster namespace.
+const provisionPodLabel = "hive.openshift.io/cluster-deployment-name"
+
+// DiagnoseCreateFailure writes the ClusterDeployment conditions and the
+// logs of the Hive provision pods to out. Call it when CreateCluster fails
+// or times out so the provisioning failure is preserved in the test
+// artifacts instead of a bare timeout error.
+func DiagnoseCreateFailure(ctx context.Context, hub client.Client, hubClientset kubernetes.Interface, clusterName string, out io.Writer) error {
+	deployment := &hivev1.ClusterDeployment{}
+	if err := hub.Get(ctx, client.ObjectKey{Namespace: clusterName, Name: clusterName}, deployment); err != nil {
+		fmt.Fprintf(out, "failed to get cluster deployment %s: %v\n", clusterName, err)
+	} else {
+		fmt.Fprintf(out, "cluster deployment %s conditions:\n", clusterName)
+		for _, condition := range deployment.Status.Conditions {
+			// Skip unset conditions to keep the dump readable.
+			if condition.Status == corev1.ConditionUnknown && condition.Reason == "Initialized" {
+				continue
+			}
+			fmt.Fprintf(out, "  %s=%s reason=%s: %s\n",
+				condition.Type, condition.Status, condition.Reason, condition.Message)
+		}
+	}
+	return StreamProvisionLogs(ctx, hubClientset, clusterName, out)
+}
+
+// StreamProvisionLogs copies the logs of all Hive provision pods of the
+// cluster into out, newest attempt last. The installer container logs carry
+// the actual cloud-side failure; the hive sidecar is included for upload
+// errors.
+func StreamProvisionLogs(ctx context.Context, hubClientset kubernetes.Interface, clusterName string, out io.Writer) error {
+	selector := fmt.Sprintf("%s=%s", provisionPodLabel, clusterName)
+	pods, err := hubClientset.CoreV1().Pods(clusterName).List(ctx, metav1.ListOptions{LabelSelector: selector})
+	if err != nil {
+		return fmt.Errorf("failed to list provision pods of cluster %s: %w", clusterName, err)
+	}
+	if len(pods.Items) == 0 {
+		fmt.Fprintf(out, "no provision pods found for cluster %s\n", clusterName)
+		return nil
+	}
+
+	for _, pod := range pods.Items {
+		for _, container := range pod.Spec.Containers {
+			fmt.Fprintf(out, "--- provision pod %s container %s ---\n", pod.Name, container.Name)
+			if err := streamContainerLogs(ctx, hubClientset, &pod, container.Name, out); err != nil {
+				fmt.Fprintf(out, "failed to stream logs: %v\n", err)
+			}
+		}
+	}
+	return nil
+}
+
+func streamContainerLogs(ctx context.Context, clientset kubernetes.Interface, pod *corev1.Pod, container string, out io.Writer) error {
+	request := clientset.CoreV1().Pods(pod.Namespace).GetLogs(pod.Name, &corev1.PodLogOptions{
+		Container: container,
+	})
+	stream, err := request.Stream(ctx)
+	if err != nil {
+		return err
+	}
+	defer stream.Close()
+	_, err = io.Copy(out, stream)
+	return err
+}
+
+// FormatProvisionFailure is a convenience wrapper returning the diagnosis
+// as a string, for attaching to a wrapped error.
+func FormatProvisionFailure(ctx context.Context, hub client.Client, hubClientset kubernetes.Interface, clusterName string) string {
+	var b strings.Builder
+	if err := DiagnoseCreateFailure(ctx, hub, hubClientset, clusterName, &b); err != nil {
+		fmt.Fprintf(&b, "diagnosis incomplete: %v\n", err)
+	}
+	return b.String()
+}
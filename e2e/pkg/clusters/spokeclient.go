@@ -0,0 +1,88 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package clusters
+
+import (
+	"context"
+	"fmt"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// adminKubeconfigBytes returns the raw admin kubeconfig of a
+// Hive-provisioned cluster from the secret referenced by its
+// ClusterDeployment.
+func adminKubeconfigBytes(ctx context.Context, hub client.Client, clusterName string) ([]byte, error) {
+	deployment := &hivev1.ClusterDeployment{}
+	if err := hub.Get(ctx, client.ObjectKey{Namespace: clusterName, Name: clusterName}, deployment); err != nil {
+		return nil, fmt.Errorf("failed to get cluster deployment %s: %w", clusterName, err)
+	}
+	if deployment.Spec.ClusterMetadata == nil {
+		return nil, fmt.Errorf("cluster %s has no cluster metadata yet; is the install finished?", clusterName)
+	}
+	secretName := deployment.Spec.ClusterMetadata.AdminKubeconfigSecretRef.Name
+	if secretName == "" {
+		return nil, fmt.Errorf("cluster %s has no admin kubeconfig secret reference", clusterName)
+	}
+
+	secret := &corev1.Secret{}
+	if err := hub.Get(ctx, client.ObjectKey{Namespace: clusterName, Name: secretName}, secret); err != nil {
+		return nil, fmt.Errorf("failed to get admin kubeconfig secret of cluster %s: %w", clusterName, err)
+	}
+	raw, ok := secret.Data["kubeconfig"]
+	if !ok {
+		return nil, fmt.Errorf("admin kubeconfig secret %s/%s has no kubeconfig key", clusterName, secretName)
+	}
+	return raw, nil
+}
+
+// SpokeRESTConfig builds a rest.Config for a Hive-provisioned cluster from
+// the admin-kubeconfig secret referenced by its ClusterDeployment, without
+// writing the kubeconfig to disk.
+func SpokeRESTConfig(ctx context.Context, hub client.Client, clusterName string) (*rest.Config, error) {
+	raw, err := adminKubeconfigBytes(ctx, hub, clusterName)
+	if err != nil {
+		return nil, err
+	}
+
+	config, err := clientcmd.RESTConfigFromKubeConfig(raw)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse admin kubeconfig of cluster %s: %w", clusterName, err)
+	}
+	return config, nil
+}
+
+// SpokeClient returns a controller-runtime client for a Hive-provisioned
+// cluster, using the scheme of the hub client so the same API types can be
+// used on both sides.
+func SpokeClient(ctx context.Context, hub client.Client, clusterName string) (client.Client, error) {
+	config, err := SpokeRESTConfig(ctx, hub, clusterName)
+	if err != nil {
+		return nil, err
+	}
+	spoke, err := client.New(config, client.Options{Scheme: hub.Scheme()})
+	if err != nil {
+		return nil, fmt.Errorf("failed to build spoke client for cluster %s: %w", clusterName, err)
+	}
+	return spoke, nil
+}
+
+// SpokeKubeClient returns a typed kubernetes clientset for a
+// Hive-provisioned cluster, for helpers that need discovery or subresources
+// not exposed through the generic client.
+func SpokeKubeClient(ctx context.Context, hub client.Client, clusterName string) (kubernetes.Interface, error) {
+	config, err := SpokeRESTConfig(ctx, hub, clusterName)
+	if err != nil {
+		return nil, err
+	}
+	clientset, err := kubernetes.NewForConfig(config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build spoke clientset for cluster %s: %w", clusterName, err)
+	}
+	return clientset, nil
+}
@@ -0,0 +1,76 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package clusters
+
+import (
+	"context"
+	"fmt"
+
+	clusterv1 "open-cluster-management.io/api/cluster/v1"
+	clusterv1beta1 "open-cluster-management.io/api/cluster/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// SetClusterTaints replaces the taints of a ManagedCluster. The scheduler
+// excludes tainted clusters from Placements that do not tolerate them.
+func SetClusterTaints(ctx context.Context, hub client.Client, clusterName string, taints []clusterv1.Taint) error {
+	return mutateManagedCluster(ctx, hub, clusterName, func(cluster *clusterv1.ManagedCluster) {
+		cluster.Spec.Taints = taints
+	})
+}
+
+// RemoveClusterTaints clears all taints from a ManagedCluster.
+func RemoveClusterTaints(ctx context.Context, hub client.Client, clusterName string) error {
+	return SetClusterTaints(ctx, hub, clusterName, nil)
+}
+
+// VerifyTaintExcludesCluster creates a Placement without tolerations and
+// asserts the tainted cluster is not selected while the rest of expected
+// clusters are. The Placement is removed afterwards.
+func VerifyTaintExcludesCluster(ctx context.Context, hub client.Client, namespace, taintedCluster string, expected []string) error {
+	name := fmt.Sprintf("taint-exclusion-%s", taintedCluster)
+	if _, err := CreatePlacement(ctx, hub, PlacementOptions{Name: name, Namespace: namespace}); err != nil {
+		return err
+	}
+	defer DeletePlacement(context.WithoutCancel(ctx), hub, namespace, name)
+
+	if err := VerifyPlacementDecision(ctx, hub, namespace, name, expected); err != nil {
+		return err
+	}
+	decided, err := GetPlacementDecisionClusters(ctx, hub, namespace, name)
+	if err != nil {
+		return err
+	}
+	for _, cluster := range decided {
+		if cluster == taintedCluster {
+			return fmt.Errorf("tainted cluster %s was selected by a placement without tolerations", taintedCluster)
+		}
+	}
+	return nil
+}
+
+// VerifyTolerationIncludesCluster creates a Placement carrying the given
+// tolerations and asserts the tainted cluster is selected again. The
+// Placement is removed afterwards.
+func VerifyTolerationIncludesCluster(ctx context.Context, hub client.Client, namespace, taintedCluster string, tolerations []clusterv1beta1.Toleration) error {
+	name := fmt.Sprintf("taint-toleration-%s", taintedCluster)
+	if _, err := CreatePlacement(ctx, hub, PlacementOptions{
+		Name:        name,
+		Namespace:   namespace,
+		Tolerations: tolerations,
+	}); err != nil {
+		return err
+	}
+	defer DeletePlacement(context.WithoutCancel(ctx), hub, namespace, name)
+
+	decided, err := GetPlacementDecisionClusters(ctx, hub, namespace, name)
+	if err != nil {
+		return err
+	}
+	for _, cluster := range decided {
+		if cluster == taintedCluster {
+			return nil
+		}
+	}
+	return fmt.Errorf("tainted cluster %s was not selected despite matching tolerations (decided: %v)", taintedCluster, decided)
+}
@@ -0,0 +1,146 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package clusters
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/watch"
+	clusterv1 "open-cluster-management.io/api/cluster/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Watch-based variants of the condition waits. A single watch reacts to
+// readiness within seconds of the status change and holds one connection
+// instead of issuing a GET every poll interval — the difference matters
+// on hubs managing thousands of clusters. The polling variants remain
+// for callers without a watch-capable client.
+
+// WatchManagedClusterCondition waits until the named ManagedCluster
+// carries the condition as True, driven by watch events.
+func WatchManagedClusterCondition(ctx context.Context, hub client.WithWatch, clusterName,
+	conditionType string, timeout time.Duration) error {
+	err := watchUntil(ctx, hub, &clusterv1.ManagedClusterList{}, timeout,
+		[]client.ListOption{client.MatchingFieldsSelector{
+			Selector: fields.OneTermEqualSelector("metadata.name", clusterName),
+		}},
+		func(obj client.Object) bool {
+			cluster, ok := obj.(*clusterv1.ManagedCluster)
+			return ok && meta.IsStatusConditionTrue(cluster.Status.Conditions, conditionType)
+		})
+	if err != nil {
+		return fmt.Errorf("cluster %s did not reach condition %s: %w", clusterName, conditionType, err)
+	}
+	return nil
+}
+
+// WatchClusterDeploymentInstalled waits until the ClusterDeployment
+// reports spec.installed, driven by watch events.
+func WatchClusterDeploymentInstalled(ctx context.Context, hub client.WithWatch, clusterName string,
+	timeout time.Duration) error {
+	err := watchUntil(ctx, hub, &hivev1.ClusterDeploymentList{}, timeout,
+		[]client.ListOption{
+			client.InNamespace(clusterName),
+			client.MatchingFieldsSelector{
+				Selector: fields.OneTermEqualSelector("metadata.name", clusterName),
+			},
+		},
+		func(obj client.Object) bool {
+			deployment, ok := obj.(*hivev1.ClusterDeployment)
+			return ok && deployment.Spec.Installed
+		})
+	if err != nil {
+		return fmt.Errorf("cluster deployment %s did not finish installing: %w", clusterName, err)
+	}
+	return nil
+}
+
+// watchUntil lists once to cover the already-done case, then follows a
+// watch from that list's resource version until done reports true. The
+// watch is re-established from the last seen resource version when the
+// server closes it, which the API server does routinely on long waits.
+func watchUntil(ctx context.Context, hub client.WithWatch, list client.ObjectList,
+	timeout time.Duration, listOptions []client.ListOption, done func(client.Object) bool) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	if err := hub.List(ctx, list, listOptions...); err != nil {
+		return fmt.Errorf("initial list failed: %w", err)
+	}
+	items, err := meta.ExtractList(list)
+	if err != nil {
+		return fmt.Errorf("failed to extract list items: %w", err)
+	}
+	for _, item := range items {
+		if obj, ok := item.(client.Object); ok && done(obj) {
+			return nil
+		}
+	}
+	resourceVersion := list.GetResourceVersion()
+
+	for {
+		watcher, err := hub.Watch(ctx, list, append(listOptions,
+			&client.ListOptions{Raw: rawListOptions(resourceVersion)})...)
+		if err != nil {
+			return fmt.Errorf("failed to start watch: %w", err)
+		}
+		finished, lastVersion, err := consumeWatch(ctx, watcher, done)
+		watcher.Stop()
+		if err != nil {
+			return err
+		}
+		if finished {
+			return nil
+		}
+		if lastVersion != "" {
+			resourceVersion = lastVersion
+		}
+		// Channel closed without the condition; re-establish the watch.
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+	}
+}
+
+// rawListOptions resumes a watch from the given resource version, with
+// bookmarks enabled so the version stays fresh through quiet periods.
+func rawListOptions(resourceVersion string) *metav1.ListOptions {
+	return &metav1.ListOptions{
+		ResourceVersion:     resourceVersion,
+		AllowWatchBookmarks: true,
+	}
+}
+
+func consumeWatch(ctx context.Context, watcher watch.Interface, done func(client.Object) bool) (bool, string, error) {
+	lastVersion := ""
+	for {
+		select {
+		case <-ctx.Done():
+			return false, lastVersion, ctx.Err()
+		case event, open := <-watcher.ResultChan():
+			if !open {
+				return false, lastVersion, nil
+			}
+			if event.Type == watch.Error {
+				return false, lastVersion, fmt.Errorf("watch error event: %v", event.Object)
+			}
+			obj, ok := event.Object.(client.Object)
+			if !ok {
+				continue
+			}
+			lastVersion = obj.GetResourceVersion()
+			if event.Type == watch.Deleted {
+				continue
+			}
+			if done(obj) {
+				return true, lastVersion, nil
+			}
+		}
+	}
+}
@@ -0,0 +1,136 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package config
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"sigs.k8s.io/yaml"
+)
+
+// Options is the typed form of the CLC options.yaml driving a run. Every
+// field the specs read is declared here, so a typo in the file fails at
+// load time with a field path instead of a nil-map panic mid-suite.
+type Options struct {
+	Hub      HubOptions       `json:"hub"`
+	Clusters []ClusterOptions `json:"clusters,omitempty"`
+	// ImageSetRefName is the default ClusterImageSet for create flows.
+	ImageSetRefName string `json:"imageSetRefName,omitempty"`
+	// OCPReleaseVersion pins the release ("4.y") when no imageset is
+	// named; defaults to latest.
+	OCPReleaseVersion string `json:"ocpReleaseVersion,omitempty"`
+}
+
+// HubOptions describes the hub under test.
+type HubOptions struct {
+	Name       string `json:"name"`
+	BaseDomain string `json:"baseDomain"`
+	// Kubeconfig defaults to the KUBECONFIG environment variable.
+	Kubeconfig string `json:"kubeconfig,omitempty"`
+}
+
+// ClusterOptions describes one managed cluster the run may create or
+// import.
+type ClusterOptions struct {
+	Name string `json:"name"`
+	// Provider is the cloud: aws, gcp, azure, vsphere, eks, gke, aks,
+	// iks, roks, rosa-hcp.
+	Provider string `json:"provider"`
+	Region   string `json:"region,omitempty"`
+	// Kubeconfig is required for import-only clusters and ignored for
+	// clusters the run creates.
+	Kubeconfig string `json:"kubeconfig,omitempty"`
+	// Workers defaults to 3.
+	Workers int `json:"workers,omitempty"`
+}
+
+// knownProviders guards against silently misspelled provider values.
+var knownOptionProviders = map[string]bool{
+	"aws": true, "gcp": true, "azure": true, "vsphere": true, "openstack": true,
+	"baremetal": true, "eks": true, "gke": true, "aks": true, "iks": true,
+	"roks": true, "rosa-hcp": true,
+}
+
+// LoadOptions reads, validates and defaults the options file. All
+// problems are collected and reported together with their field paths,
+// so one load attempt surfaces every mistake in the file.
+func LoadOptions(path string) (*Options, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read options file %s: %w", path, err)
+	}
+	options := &Options{}
+	if err := yaml.UnmarshalStrict(content, options); err != nil {
+		return nil, fmt.Errorf("failed to parse options file %s: %w", path, err)
+	}
+
+	options.applyDefaults()
+	if problems := options.validate(); len(problems) > 0 {
+		return nil, fmt.Errorf("options file %s is invalid:\n  %s", path, strings.Join(problems, "\n  "))
+	}
+	return options, nil
+}
+
+func (o *Options) applyDefaults() {
+	if o.Hub.Kubeconfig == "" {
+		o.Hub.Kubeconfig = os.Getenv("KUBECONFIG")
+	}
+	for i := range o.Clusters {
+		if o.Clusters[i].Workers == 0 {
+			o.Clusters[i].Workers = 3
+		}
+	}
+}
+
+func (o *Options) validate() []string {
+	var problems []string
+	if o.Hub.Name == "" {
+		problems = append(problems, "hub.name: required")
+	}
+	if o.Hub.BaseDomain == "" {
+		problems = append(problems, "hub.baseDomain: required")
+	}
+	if o.Hub.Kubeconfig == "" {
+		problems = append(problems, "hub.kubeconfig: required (or set KUBECONFIG)")
+	} else if _, err := os.Stat(o.Hub.Kubeconfig); err != nil {
+		problems = append(problems, fmt.Sprintf("hub.kubeconfig: %s does not exist", o.Hub.Kubeconfig))
+	}
+
+	seen := map[string]bool{}
+	for i, cluster := range o.Clusters {
+		fieldPath := fmt.Sprintf("clusters[%d]", i)
+		if cluster.Name == "" {
+			problems = append(problems, fieldPath+".name: required")
+		} else if seen[cluster.Name] {
+			problems = append(problems, fmt.Sprintf("%s.name: duplicate cluster name %q", fieldPath, cluster.Name))
+		} else {
+			seen[cluster.Name] = true
+		}
+		if cluster.Provider == "" {
+			problems = append(problems, fieldPath+".provider: required")
+		} else if !knownOptionProviders[cluster.Provider] {
+			problems = append(problems, fmt.Sprintf("%s.provider: unknown provider %q", fieldPath, cluster.Provider))
+		}
+		if cluster.Workers < 0 {
+			problems = append(problems, fmt.Sprintf("%s.workers: must not be negative", fieldPath))
+		}
+		if cluster.Kubeconfig != "" {
+			if _, err := os.Stat(cluster.Kubeconfig); err != nil {
+				problems = append(problems, fmt.Sprintf("%s.kubeconfig: %s does not exist", fieldPath, cluster.Kubeconfig))
+			}
+		}
+	}
+	return problems
+}
+
+// Cluster returns the named cluster's options.
+func (o *Options) Cluster(name string) (*ClusterOptions, error) {
+	for i := range o.Clusters {
+		if o.Clusters[i].Name == name {
+			return &o.Clusters[i], nil
+		}
+	}
+	return nil, fmt.Errorf("cluster %q is not declared in the options file", name)
+}
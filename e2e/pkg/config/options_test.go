@@ -0,0 +1,103 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeOptionsFile(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "options.yaml")
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func fakeKubeconfig(t *testing.T) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "kubeconfig")
+	if err := os.WriteFile(path, []byte("apiVersion: v1\nkind: Config\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoadOptionsValid(t *testing.T) {
+	kubeconfig := fakeKubeconfig(t)
+	path := writeOptionsFile(t, `
+hub:
+  name: hub1
+  baseDomain: dev09.example.com
+  kubeconfig: `+kubeconfig+`
+clusters:
+  - name: aws1
+    provider: aws
+    region: us-east-1
+  - name: gke1
+    provider: gke
+    workers: 1
+`)
+	options, err := LoadOptions(path)
+	if err != nil {
+		t.Fatalf("LoadOptions: %v", err)
+	}
+	// Workers defaults to 3 when unset and survives when set.
+	if options.Clusters[0].Workers != 3 || options.Clusters[1].Workers != 1 {
+		t.Errorf("workers = %d, %d; want 3, 1", options.Clusters[0].Workers, options.Clusters[1].Workers)
+	}
+
+	cluster, err := options.Cluster("gke1")
+	if err != nil || cluster.Provider != "gke" {
+		t.Errorf("Cluster(gke1) = %+v, %v", cluster, err)
+	}
+	if _, err := options.Cluster("nope"); err == nil {
+		t.Error("undeclared cluster was found")
+	}
+}
+
+func TestLoadOptionsCollectsAllProblems(t *testing.T) {
+	path := writeOptionsFile(t, `
+hub:
+  name: ""
+  kubeconfig: /does/not/exist
+clusters:
+  - name: c1
+    provider: awss
+  - name: c1
+    provider: aws
+    workers: -1
+`)
+	_, err := LoadOptions(path)
+	if err == nil {
+		t.Fatal("invalid options accepted")
+	}
+	// Every mistake is reported with its field path in one pass.
+	for _, want := range []string{
+		"hub.name: required",
+		"hub.baseDomain: required",
+		"hub.kubeconfig: /does/not/exist does not exist",
+		`clusters[0].provider: unknown provider "awss"`,
+		`clusters[1].name: duplicate cluster name "c1"`,
+		"clusters[1].workers: must not be negative",
+	} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error is missing %q:\n%v", want, err)
+		}
+	}
+}
+
+func TestLoadOptionsRejectsUnknownFields(t *testing.T) {
+	path := writeOptionsFile(t, `
+hub:
+  name: hub1
+  baseDomian: typo.example.com
+`)
+	if _, err := LoadOptions(path); err == nil {
+		t.Fatal("unknown field was accepted")
+	}
+}
@@ -0,0 +1,100 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package config
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"sigs.k8s.io/yaml"
+)
+
+// profileEnv selects the profile when no --profile flag is plumbed
+// through, so CI templates can pick a lab with one variable.
+const profileEnv = "E2E_PROFILE"
+
+// Profile describes one target environment — a dev hub, the staging lab,
+// the production-like lab — so the same suites run against any of them
+// without env-var juggling.
+type Profile struct {
+	// Hubs targeted by this profile.
+	Hubs []Hub `json:"hubs"`
+	// KubeconfigDir is scanned by the registry discovery for spoke
+	// kubeconfigs.
+	KubeconfigDir string `json:"kubeconfigDir,omitempty"`
+	// Timeouts scales the waits; slower labs declare larger values.
+	Timeouts ProfileTimeouts `json:"timeouts,omitempty"`
+	// AllowDestructive gates the destructive specs (destroy, force
+	// cleanup); production-like labs leave it false.
+	AllowDestructive bool `json:"allowDestructive,omitempty"`
+}
+
+// ProfileTimeouts holds the per-operation wait budgets as durations
+// ("90m", "10m"). Zero values keep the package defaults.
+type ProfileTimeouts struct {
+	Create  time.Duration `json:"create,omitempty"`
+	Import  time.Duration `json:"import,omitempty"`
+	Destroy time.Duration `json:"destroy,omitempty"`
+}
+
+// profilesFile is the on-disk layout: named profiles plus the default
+// selection.
+type profilesFile struct {
+	DefaultProfile string             `json:"defaultProfile,omitempty"`
+	Profiles       map[string]Profile `json:"profiles"`
+}
+
+// LoadProfile reads the profiles file and returns the selected profile:
+// the name argument when non-empty (the --profile flag), then
+// E2E_PROFILE, then the file's defaultProfile. Unknown names list the
+// available profiles in the error.
+func LoadProfile(path, name string) (*Profile, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read profiles file %s: %w", path, err)
+	}
+	parsed := &profilesFile{}
+	if err := yaml.UnmarshalStrict(content, parsed); err != nil {
+		return nil, fmt.Errorf("failed to parse profiles file %s: %w", path, err)
+	}
+	if len(parsed.Profiles) == 0 {
+		return nil, fmt.Errorf("profiles file %s declares no profiles", path)
+	}
+
+	if name == "" {
+		name = os.Getenv(profileEnv)
+	}
+	if name == "" {
+		name = parsed.DefaultProfile
+	}
+	if name == "" {
+		return nil, fmt.Errorf("no profile selected: pass --profile, set %s, or declare defaultProfile in %s",
+			profileEnv, path)
+	}
+	profile, ok := parsed.Profiles[name]
+	if !ok {
+		return nil, fmt.Errorf("profile %q not found in %s; available: %s",
+			name, path, strings.Join(profileNames(parsed.Profiles), ", "))
+	}
+	if len(profile.Hubs) == 0 {
+		return nil, fmt.Errorf("profile %q declares no hubs", name)
+	}
+	for i, hub := range profile.Hubs {
+		if hub.Name == "" || hub.Kubeconfig == "" {
+			return nil, fmt.Errorf("profile %q: hubs[%d] needs both name and kubeconfig", name, i)
+		}
+	}
+	return &profile, nil
+}
+
+func profileNames(profiles map[string]Profile) []string {
+	names := make([]string, 0, len(profiles))
+	for name := range profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,94 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+const profilesContent = `
+defaultProfile: dev
+profiles:
+  dev:
+    hubs:
+      - name: hub1
+        kubeconfig: /kubeconfigs/hub1
+    timeouts:
+      create: 5400000000000
+    allowDestructive: true
+  staging:
+    hubs:
+      - name: staging-hub
+        kubeconfig: /kubeconfigs/staging
+`
+
+func writeProfilesFile(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "profiles.yaml")
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoadProfileSelection(t *testing.T) {
+	path := writeProfilesFile(t, profilesContent)
+
+	// Explicit name wins.
+	profile, err := LoadProfile(path, "staging")
+	if err != nil {
+		t.Fatalf("LoadProfile(staging): %v", err)
+	}
+	if profile.Hubs[0].Name != "staging-hub" {
+		t.Errorf("selected %+v, want staging-hub", profile.Hubs)
+	}
+
+	// The environment variable is next.
+	t.Setenv(profileEnv, "staging")
+	if profile, err = LoadProfile(path, ""); err != nil || profile.Hubs[0].Name != "staging-hub" {
+		t.Errorf("env selection = %+v, %v", profile, err)
+	}
+
+	// Then the file's defaultProfile.
+	t.Setenv(profileEnv, "")
+	profile, err = LoadProfile(path, "")
+	if err != nil {
+		t.Fatalf("LoadProfile(default): %v", err)
+	}
+	if profile.Hubs[0].Name != "hub1" || !profile.AllowDestructive {
+		t.Errorf("default profile = %+v", profile)
+	}
+	if profile.Timeouts.Create != 90*time.Minute {
+		t.Errorf("create timeout = %s, want 90m", profile.Timeouts.Create)
+	}
+}
+
+func TestLoadProfileUnknownNameListsAvailable(t *testing.T) {
+	path := writeProfilesFile(t, profilesContent)
+	_, err := LoadProfile(path, "prod")
+	if err == nil {
+		t.Fatal("unknown profile accepted")
+	}
+	if !strings.Contains(err.Error(), "dev, staging") {
+		t.Errorf("error %v does not list the available profiles", err)
+	}
+}
+
+func TestLoadProfileValidation(t *testing.T) {
+	path := writeProfilesFile(t, `
+profiles:
+  broken:
+    hubs:
+      - name: hub1
+`)
+	if _, err := LoadProfile(path, "broken"); err == nil {
+		t.Error("hub without kubeconfig accepted")
+	}
+	if _, err := LoadProfile(path, ""); err == nil {
+		t.Error("missing selection accepted")
+	}
+}
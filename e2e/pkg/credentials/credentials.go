@@ -0,0 +1,112 @@
+// Copyright Contributors to the Open Cluster Management project
+
+// Package credentials bootstraps the cloud credential secrets that Hive
+// needs in each cluster namespace, sourcing the sensitive material from a
+// pluggable backend instead of files checked out next to the suites.
+package credentials
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Source fetches a named credential set. Implementations exist for the
+// process environment and for HashiCorp Vault.
+type Source interface {
+	// Fetch returns the key/value material of the named credential set.
+	Fetch(ctx context.Context, name string) (map[string][]byte, error)
+}
+
+// EnvSource reads credentials from environment variables. A credential set
+// named "aws" with prefix "E2E" is assembled from all variables starting
+// with "E2E_AWS_"; the remainder of the variable name, lowercased, becomes
+// the secret key. "E2E_AWS_AWS_ACCESS_KEY_ID" thus maps to
+// "aws_access_key_id".
+type EnvSource struct {
+	// Prefix defaults to "E2E" when empty.
+	Prefix string
+}
+
+// Fetch implements Source.
+func (s *EnvSource) Fetch(_ context.Context, name string) (map[string][]byte, error) {
+	prefix := s.Prefix
+	if prefix == "" {
+		prefix = "E2E"
+	}
+	needle := fmt.Sprintf("%s_%s_", prefix, strings.ToUpper(name))
+
+	material := map[string][]byte{}
+	for _, entry := range os.Environ() {
+		key, value, found := strings.Cut(entry, "=")
+		if !found || !strings.HasPrefix(key, needle) {
+			continue
+		}
+		material[strings.ToLower(strings.TrimPrefix(key, needle))] = []byte(value)
+	}
+	if len(material) == 0 {
+		return nil, fmt.Errorf("no environment variables with prefix %s found for credential set %q", needle, name)
+	}
+	return material, nil
+}
+
+// cloudSecretKeys maps a cloud to the keys Hive expects in its credential
+// secret.
+var cloudSecretKeys = map[string][]string{
+	"aws":   {"aws_access_key_id", "aws_secret_access_key"},
+	"gcp":   {"osServiceAccount.json"},
+	"azure": {"osServicePrincipal.json"},
+}
+
+// cloudSecretName returns the conventional credential secret name for a
+// cloud, matching what the console creates.
+func cloudSecretName(cloud string) string {
+	return fmt.Sprintf("%s-creds", cloud)
+}
+
+// BootstrapCloudCredentialSecret fetches the credential set for a cloud
+// from the source and writes it as the Hive credential secret in the
+// cluster namespace, returning the secret name for use in create options.
+func BootstrapCloudCredentialSecret(ctx context.Context, hub client.Client, source Source, cloud, namespace string) (string, error) {
+	required, ok := cloudSecretKeys[cloud]
+	if !ok {
+		return "", fmt.Errorf("unsupported cloud %q for credential bootstrapping", cloud)
+	}
+	material, err := source.Fetch(ctx, cloud)
+	if err != nil {
+		return "", fmt.Errorf("failed to fetch %s credentials: %w", cloud, err)
+	}
+	for _, key := range required {
+		if len(material[strings.ToLower(key)]) == 0 && len(material[key]) == 0 {
+			return "", fmt.Errorf("%s credential set is missing required key %s", cloud, key)
+		}
+	}
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      cloudSecretName(cloud),
+			Namespace: namespace,
+		},
+		Data: material,
+	}
+	if err := hub.Create(ctx, secret); err != nil {
+		if !apierrors.IsAlreadyExists(err) {
+			return "", fmt.Errorf("failed to create %s credential secret in %s: %w", cloud, namespace, err)
+		}
+		existing := &corev1.Secret{}
+		if err := hub.Get(ctx, client.ObjectKeyFromObject(secret), existing); err != nil {
+			return "", fmt.Errorf("failed to get existing %s credential secret: %w", cloud, err)
+		}
+		existing.Data = material
+		if err := hub.Update(ctx, existing); err != nil {
+			return "", fmt.Errorf("failed to update %s credential secret in %s: %w", cloud, namespace, err)
+		}
+	}
+	return secret.Name, nil
+}
@@ -0,0 +1,125 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package credentials
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	hubSecretPollInterval = 5 * time.Second
+	hubSecretWaitTimeout  = 2 * time.Minute
+)
+
+// externalSecretGVK identifies external-secrets.io ExternalSecret
+// objects, checked for sync status before their target secret is read.
+var externalSecretGVK = schema.GroupVersionKind{
+	Group: "external-secrets.io", Version: "v1beta1", Kind: "ExternalSecret",
+}
+
+// HubSecretSource fetches credential sets from Secrets already present
+// on the hub — the pattern on shared QE hubs, where ExternalSecrets or
+// SealedSecrets controllers materialize the credentials and nothing
+// sensitive lives in the test checkout. A credential set named "aws"
+// resolves to the secret "<prefix>-aws" in the configured namespace.
+type HubSecretSource struct {
+	hub client.Client
+	// Namespace holds the credential secrets.
+	Namespace string
+	// Prefix defaults to "clc-e2e".
+	Prefix string
+}
+
+// NewHubSecretSource returns a source reading from the given namespace.
+func NewHubSecretSource(hub client.Client, namespace string) *HubSecretSource {
+	return &HubSecretSource{hub: hub, Namespace: namespace, Prefix: "clc-e2e"}
+}
+
+func (s *HubSecretSource) secretName(name string) string {
+	return s.Prefix + "-" + name
+}
+
+// Fetch implements Source. When an ExternalSecret of the same name
+// manages the secret, Fetch first waits for it to report synced, so a
+// freshly rotated backend value is what the run gets; a sealed or plain
+// secret is read directly. Unsealed/unsynced material is the common
+// failure on new hubs, so both paths wait briefly for the secret to
+// materialize instead of failing on the first miss.
+func (s *HubSecretSource) Fetch(ctx context.Context, name string) (map[string][]byte, error) {
+	if err := s.waitForExternalSecretSynced(ctx, name); err != nil {
+		return nil, err
+	}
+
+	secret := &corev1.Secret{}
+	key := client.ObjectKey{Namespace: s.Namespace, Name: s.secretName(name)}
+	err := wait.PollUntilContextTimeout(ctx, hubSecretPollInterval, hubSecretWaitTimeout, true,
+		func(ctx context.Context) (bool, error) {
+			if err := s.hub.Get(ctx, key, secret); err != nil {
+				if apierrors.IsNotFound(err) {
+					return false, nil
+				}
+				return false, err
+			}
+			return len(secret.Data) > 0, nil
+		})
+	if err != nil {
+		return nil, fmt.Errorf("credential secret %s/%s did not materialize on the hub: %w",
+			key.Namespace, key.Name, err)
+	}
+	material := make(map[string][]byte, len(secret.Data))
+	for dataKey, value := range secret.Data {
+		material[dataKey] = value
+	}
+	return material, nil
+}
+
+// waitForExternalSecretSynced waits for the managing ExternalSecret to
+// report Ready. Hubs without the external-secrets CRD, or secrets not
+// managed by one, pass through immediately.
+func (s *HubSecretSource) waitForExternalSecretSynced(ctx context.Context, name string) error {
+	externalSecret := &unstructured.Unstructured{}
+	externalSecret.SetGroupVersionKind(externalSecretGVK)
+	key := client.ObjectKey{Namespace: s.Namespace, Name: s.secretName(name)}
+
+	if err := s.hub.Get(ctx, key, externalSecret); err != nil {
+		if apierrors.IsNotFound(err) || apimeta.IsNoMatchError(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to check external secret %s/%s: %w", key.Namespace, key.Name, err)
+	}
+	err := wait.PollUntilContextTimeout(ctx, hubSecretPollInterval, hubSecretWaitTimeout, true,
+		func(ctx context.Context) (bool, error) {
+			if err := s.hub.Get(ctx, key, externalSecret); err != nil {
+				return false, err
+			}
+			return externalSecretReady(externalSecret), nil
+		})
+	if err != nil {
+		return fmt.Errorf("external secret %s/%s never reported synced: %w", key.Namespace, key.Name, err)
+	}
+	return nil
+}
+
+func externalSecretReady(externalSecret *unstructured.Unstructured) bool {
+	conditions, _, _ := unstructured.NestedSlice(externalSecret.Object, "status", "conditions")
+	for _, raw := range conditions {
+		condition, ok := raw.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if condition["type"] == "Ready" && condition["status"] == "True" {
+			return true
+		}
+	}
+	return false
+}
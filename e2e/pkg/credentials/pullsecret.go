@@ -0,0 +1,99 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package credentials
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// InjectPullSecret writes the OpenShift pull secret into the cluster
+// namespace, validating that the content is a dockerconfigjson document
+// before anything is created. The source is a credential set named
+// "pullsecret" with a single ".dockerconfigjson" key.
+func InjectPullSecret(ctx context.Context, hub client.Client, source Source, namespace string) (string, error) {
+	material, err := source.Fetch(ctx, "pullsecret")
+	if err != nil {
+		return "", fmt.Errorf("failed to fetch pull secret: %w", err)
+	}
+	content, ok := material[".dockerconfigjson"]
+	if !ok && len(material) == 1 {
+		// Sources that cannot express dotted keys deliver the document as
+		// the only entry of the set.
+		for _, value := range material {
+			content, ok = value, true
+		}
+	}
+	if !ok || len(content) == 0 {
+		return "", fmt.Errorf("pull secret credential set has no .dockerconfigjson content")
+	}
+	var parsed struct {
+		Auths map[string]json.RawMessage `json:"auths"`
+	}
+	if err := json.Unmarshal(content, &parsed); err != nil || len(parsed.Auths) == 0 {
+		return "", fmt.Errorf("pull secret content is not a dockerconfigjson with auths")
+	}
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "pull-secret", Namespace: namespace},
+		Type:       corev1.SecretTypeDockerConfigJson,
+		Data:       map[string][]byte{corev1.DockerConfigJsonKey: content},
+	}
+	if err := applySecret(ctx, hub, secret); err != nil {
+		return "", err
+	}
+	return secret.Name, nil
+}
+
+// InjectSSHKey writes the SSH key pair secret Hive uses for node access
+// into the cluster namespace. The credential set is named "ssh" and must
+// carry "ssh-privatekey"; "ssh-publickey" is optional but needed for
+// install-config injection.
+func InjectSSHKey(ctx context.Context, hub client.Client, source Source, namespace string) (string, error) {
+	material, err := source.Fetch(ctx, "ssh")
+	if err != nil {
+		return "", fmt.Errorf("failed to fetch ssh key: %w", err)
+	}
+	if len(material["ssh-privatekey"]) == 0 {
+		return "", fmt.Errorf("ssh credential set has no ssh-privatekey")
+	}
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "ssh-private-key", Namespace: namespace},
+		Data: map[string][]byte{
+			"ssh-privatekey": material["ssh-privatekey"],
+		},
+	}
+	if publicKey := material["ssh-publickey"]; len(publicKey) > 0 {
+		secret.Data["ssh-publickey"] = publicKey
+	}
+	if err := applySecret(ctx, hub, secret); err != nil {
+		return "", err
+	}
+	return secret.Name, nil
+}
+
+// applySecret creates the secret or replaces its data when it exists.
+func applySecret(ctx context.Context, hub client.Client, secret *corev1.Secret) error {
+	if err := hub.Create(ctx, secret); err != nil {
+		if !apierrors.IsAlreadyExists(err) {
+			return fmt.Errorf("failed to create secret %s/%s: %w", secret.Namespace, secret.Name, err)
+		}
+		existing := &corev1.Secret{}
+		if err := hub.Get(ctx, client.ObjectKeyFromObject(secret), existing); err != nil {
+			return fmt.Errorf("failed to get existing secret %s/%s: %w", secret.Namespace, secret.Name, err)
+		}
+		existing.Data = secret.Data
+		existing.Type = secret.Type
+		if err := hub.Update(ctx, existing); err != nil {
+			return fmt.Errorf("failed to update secret %s/%s: %w", secret.Namespace, secret.Name, err)
+		}
+	}
+	return nil
+}
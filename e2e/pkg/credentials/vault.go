@@ -0,0 +1,169 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package credentials
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Vault environment variables, following the conventions of the vault
+// CLI plus the suite-specific mount settings.
+const (
+	vaultAddrEnv       = "VAULT_ADDR"
+	vaultTokenEnv      = "VAULT_TOKEN"
+	vaultRoleIDEnv     = "VAULT_ROLE_ID"
+	vaultSecretIDEnv   = "VAULT_SECRET_ID"
+	vaultMountEnv      = "VAULT_MOUNT"
+	vaultPathPrefixEnv = "VAULT_PATH_PREFIX"
+)
+
+const (
+	vaultRequestTimeout = 30 * time.Second
+	defaultVaultMount   = "secret"
+	defaultVaultPrefix  = "clc-e2e"
+)
+
+// VaultSource fetches credential sets from a HashiCorp Vault KV v2
+// mount, so kubeconfigs, cloud credentials and API tokens never sit as
+// plaintext files under resources/clusters. KV v2 secrets are static —
+// they carry no lease and nothing needs revoking at suite end; rotation
+// happens in Vault, not here.
+type VaultSource struct {
+	address    string
+	mount      string
+	pathPrefix string
+	httpClient *http.Client
+
+	mu     sync.Mutex
+	token  string
+	roleID string
+	secret string
+}
+
+// NewVaultSourceFromEnv builds a source from VAULT_* variables. It
+// returns nil when VAULT_ADDR is unset, letting callers fall back to the
+// environment source. Authentication uses VAULT_TOKEN when present,
+// otherwise an AppRole login with VAULT_ROLE_ID/VAULT_SECRET_ID.
+func NewVaultSourceFromEnv() (*VaultSource, error) {
+	address := os.Getenv(vaultAddrEnv)
+	if address == "" {
+		return nil, nil
+	}
+	source := &VaultSource{
+		address:    strings.TrimRight(address, "/"),
+		mount:      os.Getenv(vaultMountEnv),
+		pathPrefix: os.Getenv(vaultPathPrefixEnv),
+		httpClient: &http.Client{Timeout: vaultRequestTimeout},
+		token:      os.Getenv(vaultTokenEnv),
+		roleID:     os.Getenv(vaultRoleIDEnv),
+		secret:     os.Getenv(vaultSecretIDEnv),
+	}
+	if source.mount == "" {
+		source.mount = defaultVaultMount
+	}
+	if source.pathPrefix == "" {
+		source.pathPrefix = defaultVaultPrefix
+	}
+	if source.token == "" && source.roleID == "" {
+		return nil, fmt.Errorf("%s is set but neither %s nor %s/%s is", vaultAddrEnv, vaultTokenEnv, vaultRoleIDEnv, vaultSecretIDEnv)
+	}
+	return source, nil
+}
+
+// Fetch implements Source by reading <mount>/data/<prefix>/<name> and
+// returning its key/value data as secret material.
+func (s *VaultSource) Fetch(ctx context.Context, name string) (map[string][]byte, error) {
+	token, err := s.ensureToken(ctx)
+	if err != nil {
+		return nil, err
+	}
+	path := fmt.Sprintf("v1/%s/data/%s/%s", s.mount, s.pathPrefix, name)
+	body, err := s.request(ctx, http.MethodGet, path, nil, token)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read vault secret %s/%s: %w", s.pathPrefix, name, err)
+	}
+
+	var parsed struct {
+		Data struct {
+			Data map[string]string `json:"data"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(body, &parsed); err != nil {
+		return nil, fmt.Errorf("failed to parse vault secret %s/%s: %w", s.pathPrefix, name, err)
+	}
+	if len(parsed.Data.Data) == 0 {
+		return nil, fmt.Errorf("vault secret %s/%s has no data", s.pathPrefix, name)
+	}
+	material := make(map[string][]byte, len(parsed.Data.Data))
+	for key, value := range parsed.Data.Data {
+		material[key] = []byte(value)
+	}
+	return material, nil
+}
+
+// ensureToken returns the cached token, performing an AppRole login on
+// first use when no static token was configured.
+func (s *VaultSource) ensureToken(ctx context.Context) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.token != "" {
+		return s.token, nil
+	}
+	payload, err := json.Marshal(map[string]string{
+		"role_id":   s.roleID,
+		"secret_id": s.secret,
+	})
+	if err != nil {
+		return "", err
+	}
+	body, err := s.request(ctx, http.MethodPost, "v1/auth/approle/login", payload, "")
+	if err != nil {
+		return "", fmt.Errorf("vault approle login failed: %w", err)
+	}
+	var parsed struct {
+		Auth struct {
+			ClientToken string `json:"client_token"`
+		} `json:"auth"`
+	}
+	if err := json.Unmarshal(body, &parsed); err != nil || parsed.Auth.ClientToken == "" {
+		return "", fmt.Errorf("vault approle login returned no token")
+	}
+	s.token = parsed.Auth.ClientToken
+	return s.token, nil
+}
+
+func (s *VaultSource) request(ctx context.Context, method, path string, payload []byte, token string) ([]byte, error) {
+	var body io.Reader
+	if payload != nil {
+		body = bytes.NewReader(payload)
+	}
+	request, err := http.NewRequestWithContext(ctx, method, s.address+"/"+path, body)
+	if err != nil {
+		return nil, err
+	}
+	if token != "" {
+		request.Header.Set("X-Vault-Token", token)
+	}
+	response, err := s.httpClient.Do(request)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+	responseBody, err := io.ReadAll(io.LimitReader(response.Body, 1<<20))
+	if err != nil {
+		return nil, err
+	}
+	if response.StatusCode >= 300 {
+		return nil, fmt.Errorf("vault answered %d: %s", response.StatusCode, string(responseBody))
+	}
+	return responseBody, nil
+}
@@ -0,0 +1,128 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package diagnostics
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"k8s.io/client-go/rest"
+)
+
+// connectivityProbeTimeout bounds each individual probe; the checks are
+// meant to classify a failure quickly, not to wait one out.
+const connectivityProbeTimeout = 15 * time.Second
+
+// ConnectivityResult records the outcome of one probe between hub and
+// spoke.
+type ConnectivityResult struct {
+	Name  string
+	Error error
+}
+
+// ConnectivityReport aggregates the probes of one hub/spoke pair.
+type ConnectivityReport struct {
+	ClusterName string
+	Results     []ConnectivityResult
+}
+
+// Healthy reports whether every probe passed.
+func (r *ConnectivityReport) Healthy() bool {
+	for _, result := range r.Results {
+		if result.Error != nil {
+			return false
+		}
+	}
+	return true
+}
+
+// String renders the report in the pass/fail-per-line form attached to
+// failure output.
+func (r *ConnectivityReport) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "connectivity report for cluster %s:\n", r.ClusterName)
+	for _, result := range r.Results {
+		if result.Error != nil {
+			fmt.Fprintf(&b, "  FAIL %s: %v\n", result.Name, result.Error)
+		} else {
+			fmt.Fprintf(&b, "  ok   %s\n", result.Name)
+		}
+	}
+	return b.String()
+}
+
+// CheckHubSpokeConnectivity probes the network paths registration depends
+// on: the spoke API server from the test runner, the hub API server (the
+// registration endpoint the klusterlet dials), and the hub's cluster
+// proxy path to the spoke. The report lets a failing import be classified
+// as an infrastructure problem before it is filed as a product bug.
+func CheckHubSpokeConnectivity(ctx context.Context, hubConfig, spokeConfig *rest.Config, clusterName string) *ConnectivityReport {
+	report := &ConnectivityReport{ClusterName: clusterName}
+	report.Results = append(report.Results, ConnectivityResult{
+		Name:  "spoke API server " + spokeConfig.Host,
+		Error: probeEndpoint(ctx, spokeConfig.Host+"/healthz"),
+	})
+	report.Results = append(report.Results, ConnectivityResult{
+		Name:  "hub API server " + hubConfig.Host,
+		Error: probeEndpoint(ctx, hubConfig.Host+"/healthz"),
+	})
+	proxyPath := fmt.Sprintf("%s/apis/proxy.open-cluster-management.io/v1beta1/namespaces/%s/clusterstatuses/%s/aggregator/healthz",
+		hubConfig.Host, clusterName, clusterName)
+	report.Results = append(report.Results, ConnectivityResult{
+		Name:  "hub proxy path to " + clusterName,
+		Error: probeAuthenticatedEndpoint(ctx, hubConfig, proxyPath),
+	})
+	return report
+}
+
+// probeEndpoint checks plain TCP/TLS reachability of an endpoint. Any
+// HTTP status counts as reachable — 401/403 still prove the network path
+// and the serving certificate handshake work.
+func probeEndpoint(ctx context.Context, url string) error {
+	ctx, cancel := context.WithTimeout(ctx, connectivityProbeTimeout)
+	defer cancel()
+	httpClient := &http.Client{
+		Transport: &http.Transport{
+			TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
+		},
+	}
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return fmt.Errorf("failed to build probe request: %w", err)
+	}
+	response, err := httpClient.Do(request)
+	if err != nil {
+		return fmt.Errorf("unreachable: %w", err)
+	}
+	response.Body.Close()
+	return nil
+}
+
+// probeAuthenticatedEndpoint checks an endpoint with the config's
+// credentials, where an authorization failure is a real failure.
+func probeAuthenticatedEndpoint(ctx context.Context, config *rest.Config, url string) error {
+	ctx, cancel := context.WithTimeout(ctx, connectivityProbeTimeout)
+	defer cancel()
+	transportConfig := rest.CopyConfig(config)
+	httpClient, err := rest.HTTPClientFor(transportConfig)
+	if err != nil {
+		return fmt.Errorf("failed to build authenticated client: %w", err)
+	}
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return fmt.Errorf("failed to build probe request: %w", err)
+	}
+	response, err := httpClient.Do(request)
+	if err != nil {
+		return fmt.Errorf("unreachable: %w", err)
+	}
+	defer response.Body.Close()
+	if response.StatusCode >= 400 {
+		return fmt.Errorf("answered %d", response.StatusCode)
+	}
+	return nil
+}
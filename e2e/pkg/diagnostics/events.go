@@ -0,0 +1,80 @@
+// Copyright Contributors to the Open Cluster Management project
+
+// Package diagnostics collects debugging artifacts — events, must-gather,
+// resource snapshots — around failing specs, so CI failures are triageable
+// without re-running against the live environment.
+package diagnostics
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/onsi/ginkgo/v2"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// CaptureEventsOnFailure registers a JustAfterEach hook that, when the
+// current spec failed, attaches the recent events of the given namespaces
+// to the spec report. namespaces is evaluated at failure time so hooks can
+// be registered before the namespaces under test exist.
+//
+// Call it from a suite's top-level Describe or BeforeEach context.
+func CaptureEventsOnFailure(hub client.Client, namespaces func() []string) {
+	ginkgo.JustAfterEach(func(ctx context.Context) {
+		if !ginkgo.CurrentSpecReport().Failed() {
+			return
+		}
+		for _, namespace := range namespaces() {
+			dump, err := FormatNamespaceEvents(ctx, hub, namespace)
+			if err != nil {
+				dump = fmt.Sprintf("failed to collect events: %v", err)
+			}
+			ginkgo.AddReportEntry(
+				fmt.Sprintf("events in %s", namespace),
+				ginkgo.ReportEntryVisibilityFailureOrVerbose,
+				dump,
+			)
+		}
+	})
+}
+
+// FormatNamespaceEvents returns the namespace's events sorted by last
+// timestamp, newest last, in a compact one-line-per-event format.
+func FormatNamespaceEvents(ctx context.Context, c client.Client, namespace string) (string, error) {
+	events := &corev1.EventList{}
+	if err := c.List(ctx, events, client.InNamespace(namespace)); err != nil {
+		return "", fmt.Errorf("failed to list events in %s: %w", namespace, err)
+	}
+	if len(events.Items) == 0 {
+		return "no events", nil
+	}
+
+	sorted := events.Items
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].LastTimestamp.Before(&sorted[j].LastTimestamp)
+	})
+
+	var b strings.Builder
+	for _, event := range sorted {
+		fmt.Fprintf(&b, "%s %s %s %s/%s: %s (x%d)\n",
+			event.LastTimestamp.Format("15:04:05"),
+			event.Type,
+			event.Reason,
+			event.InvolvedObject.Kind,
+			event.InvolvedObject.Name,
+			event.Message,
+			max(event.Count, 1),
+		)
+	}
+	return b.String(), nil
+}
+
+func max(a, b int32) int32 {
+	if a > b {
+		return a
+	}
+	return b
+}
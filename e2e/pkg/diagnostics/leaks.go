@@ -0,0 +1,139 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package diagnostics
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// RunIDLabel tags every resource a suite run creates, so leftovers can be
+// attributed to — and swept for — a specific run.
+const RunIDLabel = "e2e.open-cluster-management.io/run-id"
+
+// LeakTracker records the hub resources a suite creates and reports, in
+// AfterSuite, anything the specs failed to clean up. Helpers register
+// objects at creation time and deregister them when their cleanup runs.
+type LeakTracker struct {
+	hub   client.Client
+	runID string
+
+	mu    sync.Mutex
+	owned []client.Object
+}
+
+// NewLeakTracker returns a tracker for one suite run.
+func NewLeakTracker(hub client.Client, runID string) *LeakTracker {
+	return &LeakTracker{hub: hub, runID: runID}
+}
+
+// Track records an object as owned by this run and stamps it with the run
+// ID label so an out-of-band sweep can also find it.
+func (t *LeakTracker) Track(obj client.Object) {
+	labels := obj.GetLabels()
+	if labels == nil {
+		labels = map[string]string{}
+	}
+	labels[RunIDLabel] = t.runID
+	obj.SetLabels(labels)
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.owned = append(t.owned, obj)
+}
+
+// Release drops an object from the tracker after its cleanup succeeded.
+func (t *LeakTracker) Release(obj client.Object) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for i, owned := range t.owned {
+		if sameObject(owned, obj) {
+			t.owned = append(t.owned[:i], t.owned[i+1:]...)
+			return
+		}
+	}
+}
+
+func sameObject(a, b client.Object) bool {
+	return a.GetObjectKind().GroupVersionKind() == b.GetObjectKind().GroupVersionKind() &&
+		a.GetNamespace() == b.GetNamespace() &&
+		a.GetName() == b.GetName()
+}
+
+// Leaks returns the tracked objects that still exist on the hub. Objects
+// already gone are not leaks regardless of whether Release was called.
+func (t *LeakTracker) Leaks(ctx context.Context) ([]client.Object, error) {
+	t.mu.Lock()
+	owned := make([]client.Object, len(t.owned))
+	copy(owned, t.owned)
+	t.mu.Unlock()
+
+	var leaks []client.Object
+	for _, obj := range owned {
+		current := obj.DeepCopyObject().(client.Object)
+		err := t.hub.Get(ctx, client.ObjectKeyFromObject(obj), current)
+		if errors.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to check tracked resource %s: %w", describeObject(obj), err)
+		}
+		leaks = append(leaks, current)
+	}
+	return leaks, nil
+}
+
+// Report returns a human-readable leak summary for AfterSuite output, or
+// an empty string when the run cleaned up after itself.
+func (t *LeakTracker) Report(ctx context.Context) (string, error) {
+	leaks, err := t.Leaks(ctx)
+	if err != nil {
+		return "", err
+	}
+	if len(leaks) == 0 {
+		return "", nil
+	}
+	lines := make([]string, 0, len(leaks))
+	for _, leak := range leaks {
+		lines = append(lines, describeObject(leak))
+	}
+	return fmt.Sprintf("run %s leaked %d resources:\n  %s",
+		t.runID, len(leaks), strings.Join(lines, "\n  ")), nil
+}
+
+// Sweep deletes every leaked resource that still carries this run's ID
+// label. Resources without the label are reported but left alone — they
+// may have been adopted or relabeled on purpose.
+func (t *LeakTracker) Sweep(ctx context.Context) ([]client.Object, error) {
+	leaks, err := t.Leaks(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var skipped []client.Object
+	for _, leak := range leaks {
+		if leak.GetLabels()[RunIDLabel] != t.runID {
+			skipped = append(skipped, leak)
+			continue
+		}
+		if err := t.hub.Delete(ctx, leak); err != nil && !errors.IsNotFound(err) {
+			return nil, fmt.Errorf("failed to sweep leaked resource %s: %w", describeObject(leak), err)
+		}
+	}
+	return skipped, nil
+}
+
+func describeObject(obj client.Object) string {
+	kind := obj.GetObjectKind().GroupVersionKind().Kind
+	if kind == "" {
+		kind = fmt.Sprintf("%T", obj)
+	}
+	if obj.GetNamespace() == "" {
+		return fmt.Sprintf("%s %s", kind, obj.GetName())
+	}
+	return fmt.Sprintf("%s %s/%s", kind, obj.GetNamespace(), obj.GetName())
+}
@@ -0,0 +1,95 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package diagnostics
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"time"
+
+	"github.com/onsi/ginkgo/v2"
+)
+
+// defaultMustGatherImage collects ACM hub and agent data.
+const defaultMustGatherImage = "quay.io/stolostron/must-gather:latest"
+
+// MustGatherOptions configures a must-gather collection.
+type MustGatherOptions struct {
+	// KubeconfigPath points oc at the hub.
+	KubeconfigPath string
+	// Image overrides the must-gather image; empty selects the ACM image.
+	Image string
+	// DestDir is the output directory; empty derives one under the
+	// artifact directory from ARTIFACT_DIR or the working directory.
+	DestDir string
+	// Timeout bounds the collection; must-gather on a large hub can run
+	// for many minutes. Zero means 15 minutes.
+	Timeout time.Duration
+}
+
+// CollectMustGather runs `oc adm must-gather` against the hub and returns
+// the directory holding the collected data.
+func CollectMustGather(ctx context.Context, opts MustGatherOptions) (string, error) {
+	image := opts.Image
+	if image == "" {
+		image = defaultMustGatherImage
+	}
+	destDir := opts.DestDir
+	if destDir == "" {
+		base := os.Getenv("ARTIFACT_DIR")
+		if base == "" {
+			base = "."
+		}
+		destDir = filepath.Join(base, fmt.Sprintf("must-gather-%s", time.Now().Format("20060102-150405")))
+	}
+	if err := os.MkdirAll(destDir, 0o755); err != nil {
+		return "", fmt.Errorf("failed to create must-gather directory %s: %w", destDir, err)
+	}
+	timeout := opts.Timeout
+	if timeout == 0 {
+		timeout = 15 * time.Minute
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	cmd := exec.CommandContext(ctx, "oc", "adm", "must-gather",
+		"--image", image,
+		"--dest-dir", destDir,
+	)
+	if opts.KubeconfigPath != "" {
+		cmd.Env = append(os.Environ(), "KUBECONFIG="+opts.KubeconfigPath)
+	}
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return destDir, fmt.Errorf("must-gather failed: %w\n%s", err, string(output))
+	}
+	return destDir, nil
+}
+
+// CollectMustGatherOnSuiteFailure registers a ReportAfterSuite hook that
+// runs a must-gather when any spec in the suite failed. The collection is
+// best-effort: a must-gather failure is reported but never fails the
+// suite.
+func CollectMustGatherOnSuiteFailure(opts MustGatherOptions) {
+	ginkgo.ReportAfterSuite("must-gather on failure", func(report ginkgo.Report) {
+		failed := false
+		for _, spec := range report.SpecReports {
+			if spec.Failed() {
+				failed = true
+				break
+			}
+		}
+		if !failed {
+			return
+		}
+		dir, err := CollectMustGather(context.Background(), opts)
+		if err != nil {
+			ginkgo.GinkgoWriter.Printf("must-gather collection failed: %v\n", err)
+			return
+		}
+		ginkgo.GinkgoWriter.Printf("must-gather collected into %s\n", dir)
+	})
+}
@@ -0,0 +1,52 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package diagnostics
+
+import (
+	"fmt"
+	"math/rand"
+	"time"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// OwnerLabel marks resources as owned by this automation, the value QE
+// uses to tell auto-created resources from manually staged ones.
+const (
+	OwnerLabel   = "e2e.open-cluster-management.io/owner"
+	DefaultOwner = "acmqe-clc-auto"
+)
+
+const runIDAlphabet = "abcdefghijklmnopqrstuvwxyz0123456789"
+
+// NewRunID generates a unique, DNS-label-safe run identifier, e.g.
+// "clc-20260830-x7k2q". It doubles as the name prefix for everything the
+// run creates, so even unlabeled cloud resources remain attributable.
+func NewRunID() string {
+	suffix := make([]byte, 5)
+	for i := range suffix {
+		suffix[i] = runIDAlphabet[rand.Intn(len(runIDAlphabet))]
+	}
+	return fmt.Sprintf("clc-%s-%s", time.Now().UTC().Format("20060102"), suffix)
+}
+
+// StampRunLabels tags an object with the owner and run ID labels; every
+// helper that creates hub resources calls this so `cleanup --run-id` can
+// find them later.
+func StampRunLabels(obj client.Object, runID string) {
+	labels := obj.GetLabels()
+	if labels == nil {
+		labels = map[string]string{}
+	}
+	labels[OwnerLabel] = DefaultOwner
+	labels[RunIDLabel] = runID
+	obj.SetLabels(labels)
+}
+
+// RunSelector returns the label selector matching one run's resources.
+func RunSelector(runID string) client.MatchingLabels {
+	return client.MatchingLabels{
+		OwnerLabel: DefaultOwner,
+		RunIDLabel: runID,
+	}
+}
@@ -0,0 +1,101 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package diagnostics
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Snapshot captures the resource versions of selected resource kinds at a
+// point in time, so two snapshots can be diffed to see what a scenario
+// created, changed or deleted.
+type Snapshot struct {
+	// objects maps "kind/namespace/name" to resourceVersion.
+	objects map[string]string
+}
+
+// TakeSnapshot lists the given resource kinds (cluster-wide when namespace
+// is empty) and records every object with its resource version.
+func TakeSnapshot(ctx context.Context, c client.Client, namespace string, kinds []schema.GroupVersionKind) (*Snapshot, error) {
+	snapshot := &Snapshot{objects: map[string]string{}}
+	for _, gvk := range kinds {
+		list := &unstructured.UnstructuredList{}
+		list.SetGroupVersionKind(schema.GroupVersionKind{
+			Group:   gvk.Group,
+			Version: gvk.Version,
+			Kind:    gvk.Kind + "List",
+		})
+		var opts []client.ListOption
+		if namespace != "" {
+			opts = append(opts, client.InNamespace(namespace))
+		}
+		if err := c.List(ctx, list, opts...); err != nil {
+			return nil, fmt.Errorf("failed to list %s for snapshot: %w", gvk.Kind, err)
+		}
+		for _, item := range list.Items {
+			key := fmt.Sprintf("%s/%s/%s", gvk.Kind, item.GetNamespace(), item.GetName())
+			snapshot.objects[key] = item.GetResourceVersion()
+		}
+	}
+	return snapshot, nil
+}
+
+// SnapshotDiff is the difference between two snapshots.
+type SnapshotDiff struct {
+	Added   []string
+	Changed []string
+	Removed []string
+}
+
+// Empty reports whether the snapshots are identical.
+func (d *SnapshotDiff) Empty() bool {
+	return len(d.Added) == 0 && len(d.Changed) == 0 && len(d.Removed) == 0
+}
+
+// String renders the diff for test output.
+func (d *SnapshotDiff) String() string {
+	if d.Empty() {
+		return "no resource changes"
+	}
+	var b strings.Builder
+	for _, key := range d.Added {
+		fmt.Fprintf(&b, "+ %s\n", key)
+	}
+	for _, key := range d.Changed {
+		fmt.Fprintf(&b, "~ %s\n", key)
+	}
+	for _, key := range d.Removed {
+		fmt.Fprintf(&b, "- %s\n", key)
+	}
+	return b.String()
+}
+
+// Diff compares the snapshot against a later one.
+func (s *Snapshot) Diff(later *Snapshot) *SnapshotDiff {
+	diff := &SnapshotDiff{}
+	for key, version := range later.objects {
+		before, existed := s.objects[key]
+		switch {
+		case !existed:
+			diff.Added = append(diff.Added, key)
+		case before != version:
+			diff.Changed = append(diff.Changed, key)
+		}
+	}
+	for key := range s.objects {
+		if _, still := later.objects[key]; !still {
+			diff.Removed = append(diff.Removed, key)
+		}
+	}
+	sort.Strings(diff.Added)
+	sort.Strings(diff.Changed)
+	sort.Strings(diff.Removed)
+	return diff
+}
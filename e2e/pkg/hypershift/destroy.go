@@ -0,0 +1,119 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package hypershift
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/resourcegroupstaggingapi"
+	"github.com/aws/aws-sdk-go-v2/service/resourcegroupstaggingapi/types"
+	hyperv1 "github.com/openshift/hypershift/api/hypershift/v1beta1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const destroyTimeout = 30 * time.Minute
+
+// DestroyOptions configures a hosted cluster teardown.
+type DestroyOptions struct {
+	Namespace string
+	Name      string
+	// SweepAWS, when set, lists AWS resources still tagged with the
+	// cluster ID after teardown and reports them as leaks. Requires the
+	// standard AWS credential chain and the cluster's region.
+	SweepAWS  bool
+	AWSRegion string
+}
+
+// DestroyHostedCluster deletes the NodePools and the HostedCluster, waits
+// for the teardown to complete and, when requested, reports AWS resources
+// still carrying the cluster's ownership tag. The returned slice holds the
+// ARNs of leaked resources; an empty slice means a clean teardown.
+func DestroyHostedCluster(ctx context.Context, hub client.Client, opts DestroyOptions) ([]string, error) {
+	hostedCluster := &hyperv1.HostedCluster{}
+	key := client.ObjectKey{Namespace: opts.Namespace, Name: opts.Name}
+	if err := hub.Get(ctx, key, hostedCluster); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get hosted cluster %s: %w", key, err)
+	}
+	infraID := hostedCluster.Spec.InfraID
+
+	nodePools := &hyperv1.NodePoolList{}
+	if err := hub.List(ctx, nodePools, client.InNamespace(opts.Namespace)); err != nil {
+		return nil, fmt.Errorf("failed to list node pools in %s: %w", opts.Namespace, err)
+	}
+	for i := range nodePools.Items {
+		nodePool := &nodePools.Items[i]
+		if nodePool.Spec.ClusterName != opts.Name {
+			continue
+		}
+		if err := hub.Delete(ctx, nodePool); err != nil && !apierrors.IsNotFound(err) {
+			return nil, fmt.Errorf("failed to delete node pool %s: %w", nodePool.Name, err)
+		}
+	}
+
+	if err := hub.Delete(ctx, hostedCluster); err != nil && !apierrors.IsNotFound(err) {
+		return nil, fmt.Errorf("failed to delete hosted cluster %s: %w", key, err)
+	}
+	err := wait.PollUntilContextTimeout(ctx, nodePoolPollInterval, destroyTimeout, true,
+		func(ctx context.Context) (bool, error) {
+			err := hub.Get(ctx, key, hostedCluster)
+			if apierrors.IsNotFound(err) {
+				return true, nil
+			}
+			return false, err
+		})
+	if err != nil {
+		return nil, fmt.Errorf("hosted cluster %s was not torn down: %w", key, err)
+	}
+
+	if !opts.SweepAWS {
+		return nil, nil
+	}
+	return sweepAWSResources(ctx, opts.AWSRegion, infraID)
+}
+
+// sweepAWSResources returns the ARNs of AWS resources still tagged as owned
+// by the hosted cluster's infra ID. HyperShift tags every resource it
+// creates with "kubernetes.io/cluster/<infraID>=owned", so anything left
+// after teardown is a leak.
+func sweepAWSResources(ctx context.Context, region, infraID string) ([]string, error) {
+	if infraID == "" {
+		return nil, fmt.Errorf("hosted cluster has no infra ID; cannot sweep AWS resources")
+	}
+	cfg, err := awsconfig.LoadDefaultConfig(ctx, awsconfig.WithRegion(region))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load AWS configuration: %w", err)
+	}
+	tagging := resourcegroupstaggingapi.NewFromConfig(cfg)
+
+	var leaked []string
+	var token *string
+	for {
+		out, err := tagging.GetResources(ctx, &resourcegroupstaggingapi.GetResourcesInput{
+			PaginationToken: token,
+			TagFilters: []types.TagFilter{{
+				Key:    aws.String(fmt.Sprintf("kubernetes.io/cluster/%s", infraID)),
+				Values: []string{"owned"},
+			}},
+		})
+		if err != nil {
+			return leaked, fmt.Errorf("failed to list AWS resources tagged for %s: %w", infraID, err)
+		}
+		for _, mapping := range out.ResourceTagMappingList {
+			leaked = append(leaked, aws.ToString(mapping.ResourceARN))
+		}
+		if out.PaginationToken == nil || *out.PaginationToken == "" {
+			break
+		}
+		token = out.PaginationToken
+	}
+	return leaked, nil
+}
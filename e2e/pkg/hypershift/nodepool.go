@@ -0,0 +1,109 @@
+// Copyright Contributors to the Open Cluster Management project
+
+// Package hypershift provides lifecycle helpers for HyperShift hosted
+// clusters, parallel to the Hive helpers in pkg/clusters but operating on
+// the hypershift.openshift.io API group.
+package hypershift
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	hyperv1 "github.com/openshift/hypershift/api/hypershift/v1beta1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	nodePoolPollInterval = 15 * time.Second
+	nodePoolWaitTimeout  = 20 * time.Minute
+)
+
+// ScaleNodePool sets a NodePool to an absolute replica count and waits for
+// the current replicas to converge. The NodePool must not have autoscaling
+// enabled.
+func ScaleNodePool(ctx context.Context, hub client.Client, namespace, name string, replicas int32) error {
+	if replicas < 0 {
+		return fmt.Errorf("node pool replica target must be non-negative, got %d", replicas)
+	}
+	nodePool := &hyperv1.NodePool{}
+	key := client.ObjectKey{Namespace: namespace, Name: name}
+	if err := hub.Get(ctx, key, nodePool); err != nil {
+		return fmt.Errorf("failed to get node pool %s: %w", key, err)
+	}
+	if nodePool.Spec.AutoScaling != nil {
+		return fmt.Errorf("node pool %s has autoscaling enabled; disable it before setting replicas", key)
+	}
+	nodePool.Spec.Replicas = ptr.To(replicas)
+	if err := hub.Update(ctx, nodePool); err != nil {
+		return fmt.Errorf("failed to scale node pool %s to %d: %w", key, replicas, err)
+	}
+	return WaitForNodePoolReady(ctx, hub, namespace, name, replicas)
+}
+
+// NodePoolScalingCheck scales a NodePool by delta replicas and verifies it
+// converges, mirroring MachinePoolScalingCheck for hosted clusters.
+func NodePoolScalingCheck(ctx context.Context, hub client.Client, namespace, name string, delta int32) error {
+	nodePool := &hyperv1.NodePool{}
+	key := client.ObjectKey{Namespace: namespace, Name: name}
+	if err := hub.Get(ctx, key, nodePool); err != nil {
+		return fmt.Errorf("failed to get node pool %s: %w", key, err)
+	}
+	if nodePool.Spec.Replicas == nil {
+		return fmt.Errorf("node pool %s has no explicit replicas; cannot scale by delta", key)
+	}
+	target := *nodePool.Spec.Replicas + delta
+	if target < 0 {
+		return fmt.Errorf("scaling node pool %s by %d would result in negative replicas", key, delta)
+	}
+	return ScaleNodePool(ctx, hub, namespace, name, target)
+}
+
+// EnableNodePoolAutoscaling switches a NodePool from fixed replicas to
+// autoscaling between min and max.
+func EnableNodePoolAutoscaling(ctx context.Context, hub client.Client, namespace, name string, min, max int32) error {
+	if min < 1 || max < min {
+		return fmt.Errorf("invalid autoscaling bounds [%d, %d] for node pool %s", min, max, name)
+	}
+	nodePool := &hyperv1.NodePool{}
+	key := client.ObjectKey{Namespace: namespace, Name: name}
+	if err := hub.Get(ctx, key, nodePool); err != nil {
+		return fmt.Errorf("failed to get node pool %s: %w", key, err)
+	}
+	nodePool.Spec.Replicas = nil
+	nodePool.Spec.AutoScaling = &hyperv1.NodePoolAutoScaling{Min: ptr.To(min), Max: max}
+	if err := hub.Update(ctx, nodePool); err != nil {
+		return fmt.Errorf("failed to enable autoscaling on node pool %s: %w", key, err)
+	}
+	// With autoscaling the pool settles anywhere within the bounds; wait
+	// for at least the minimum.
+	return waitForNodePool(ctx, hub, namespace, name, func(nodePool *hyperv1.NodePool) bool {
+		return nodePool.Status.Replicas >= min
+	})
+}
+
+// WaitForNodePoolReady waits until the NodePool reports exactly the wanted
+// current replicas.
+func WaitForNodePoolReady(ctx context.Context, hub client.Client, namespace, name string, replicas int32) error {
+	return waitForNodePool(ctx, hub, namespace, name, func(nodePool *hyperv1.NodePool) bool {
+		return nodePool.Status.Replicas == replicas
+	})
+}
+
+func waitForNodePool(ctx context.Context, hub client.Client, namespace, name string, done func(*hyperv1.NodePool) bool) error {
+	nodePool := &hyperv1.NodePool{}
+	err := wait.PollUntilContextTimeout(ctx, nodePoolPollInterval, nodePoolWaitTimeout, true,
+		func(ctx context.Context) (bool, error) {
+			if err := hub.Get(ctx, client.ObjectKey{Namespace: namespace, Name: name}, nodePool); err != nil {
+				return false, err
+			}
+			return done(nodePool), nil
+		})
+	if err != nil {
+		return fmt.Errorf("node pool %s/%s did not converge (current replicas %d): %w",
+			namespace, name, nodePool.Status.Replicas, err)
+	}
+	return nil
+}
@@ -0,0 +1,167 @@
+// Copyright Contributors to the Open Cluster Management project
+
+// Package matchers provides Gomega matchers for the ACM resources the
+// suite asserts on most, so specs read as intent and failures print the
+// relevant status instead of a full object dump.
+package matchers
+
+import (
+	"fmt"
+
+	"github.com/onsi/gomega/format"
+	"github.com/onsi/gomega/types"
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	clusterv1 "open-cluster-management.io/api/cluster/v1"
+)
+
+// BeAvailable succeeds when the actual resource reports it is ready for
+// use: ManagedClusterConditionAvailable for a ManagedCluster, Installed
+// for a ClusterDeployment, or Replicas == ReadyReplicas for a MachinePool.
+func BeAvailable() types.GomegaMatcher {
+	return &beAvailableMatcher{}
+}
+
+type beAvailableMatcher struct{}
+
+func (m *beAvailableMatcher) Match(actual interface{}) (bool, error) {
+	switch obj := actual.(type) {
+	case *clusterv1.ManagedCluster:
+		return meta.IsStatusConditionTrue(obj.Status.Conditions, clusterv1.ManagedClusterConditionAvailable), nil
+	case *hivev1.ClusterDeployment:
+		return obj.Spec.Installed, nil
+	case *hivev1.MachinePool:
+		return obj.Status.Replicas > 0 && obj.Status.Replicas == readyPoolReplicas(obj), nil
+	default:
+		return false, fmt.Errorf("BeAvailable matcher does not support %T", actual)
+	}
+}
+
+func readyPoolReplicas(pool *hivev1.MachinePool) int32 {
+	var ready int32
+	for _, machineSet := range pool.Status.MachineSets {
+		ready += machineSet.ReadyReplicas
+	}
+	return ready
+}
+
+func (m *beAvailableMatcher) FailureMessage(actual interface{}) string {
+	return format.Message(describeResource(actual), "to be available")
+}
+
+func (m *beAvailableMatcher) NegatedFailureMessage(actual interface{}) string {
+	return format.Message(describeResource(actual), "not to be available")
+}
+
+// HaveCondition succeeds when the actual resource carries a condition of
+// the given type with the given status. It understands both
+// metav1.Condition lists (ManagedCluster) and Hive's typed condition
+// lists (ClusterDeployment, MachinePool).
+func HaveCondition(conditionType string, status corev1.ConditionStatus) types.GomegaMatcher {
+	return &haveConditionMatcher{conditionType: conditionType, status: status}
+}
+
+type haveConditionMatcher struct {
+	conditionType string
+	status        corev1.ConditionStatus
+}
+
+func (m *haveConditionMatcher) Match(actual interface{}) (bool, error) {
+	switch obj := actual.(type) {
+	case *clusterv1.ManagedCluster:
+		condition := meta.FindStatusCondition(obj.Status.Conditions, m.conditionType)
+		return condition != nil && string(condition.Status) == string(m.status), nil
+	case *hivev1.ClusterDeployment:
+		for _, condition := range obj.Status.Conditions {
+			if string(condition.Type) == m.conditionType {
+				return condition.Status == m.status, nil
+			}
+		}
+		return false, nil
+	case *hivev1.MachinePool:
+		for _, condition := range obj.Status.Conditions {
+			if string(condition.Type) == m.conditionType {
+				return condition.Status == m.status, nil
+			}
+		}
+		return false, nil
+	default:
+		return false, fmt.Errorf("HaveCondition matcher does not support %T", actual)
+	}
+}
+
+func (m *haveConditionMatcher) FailureMessage(actual interface{}) string {
+	return format.Message(describeResource(actual),
+		fmt.Sprintf("to have condition %s=%s", m.conditionType, m.status))
+}
+
+func (m *haveConditionMatcher) NegatedFailureMessage(actual interface{}) string {
+	return format.Message(describeResource(actual),
+		fmt.Sprintf("not to have condition %s=%s", m.conditionType, m.status))
+}
+
+// HaveLabelSelectorMatch succeeds when the actual resource's labels match
+// the given selector, the assertion behind set membership and placement
+// predicate checks.
+func HaveLabelSelectorMatch(selector *metav1.LabelSelector) types.GomegaMatcher {
+	return &haveLabelSelectorMatchMatcher{selector: selector}
+}
+
+type haveLabelSelectorMatchMatcher struct {
+	selector *metav1.LabelSelector
+}
+
+func (m *haveLabelSelectorMatchMatcher) Match(actual interface{}) (bool, error) {
+	obj, ok := actual.(metav1.Object)
+	if !ok {
+		return false, fmt.Errorf("HaveLabelSelectorMatch matcher requires a metav1.Object, got %T", actual)
+	}
+	selector, err := metav1.LabelSelectorAsSelector(m.selector)
+	if err != nil {
+		return false, fmt.Errorf("invalid label selector: %w", err)
+	}
+	return selector.Matches(labels.Set(obj.GetLabels())), nil
+}
+
+func (m *haveLabelSelectorMatchMatcher) FailureMessage(actual interface{}) string {
+	return format.Message(describeResource(actual),
+		fmt.Sprintf("to match label selector %v", m.selector))
+}
+
+func (m *haveLabelSelectorMatchMatcher) NegatedFailureMessage(actual interface{}) string {
+	return format.Message(describeResource(actual),
+		fmt.Sprintf("not to match label selector %v", m.selector))
+}
+
+// describeResource prints the status portion a failure actually needs
+// rather than the full object.
+func describeResource(actual interface{}) string {
+	switch obj := actual.(type) {
+	case *clusterv1.ManagedCluster:
+		return fmt.Sprintf("ManagedCluster %s (conditions: %s)", obj.Name, summarizeConditions(obj.Status.Conditions))
+	case *hivev1.ClusterDeployment:
+		return fmt.Sprintf("ClusterDeployment %s/%s (installed: %t)", obj.Namespace, obj.Name, obj.Spec.Installed)
+	case *hivev1.MachinePool:
+		return fmt.Sprintf("MachinePool %s/%s (replicas: %d, ready: %d)",
+			obj.Namespace, obj.Name, obj.Status.Replicas, readyPoolReplicas(obj))
+	default:
+		return fmt.Sprintf("%T", actual)
+	}
+}
+
+func summarizeConditions(conditions []metav1.Condition) string {
+	if len(conditions) == 0 {
+		return "none"
+	}
+	summary := ""
+	for i, condition := range conditions {
+		if i > 0 {
+			summary += ", "
+		}
+		summary += fmt.Sprintf("%s=%s", condition.Type, condition.Status)
+	}
+	return summary
+}
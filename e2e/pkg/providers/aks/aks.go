@@ -0,0 +1,149 @@
+// Copyright Contributors to the Open Cluster Management project
+
+// Package aks provisions Azure Kubernetes Service clusters through the
+// Azure SDK as import prerequisites for the e2e suites.
+package aks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/to"
+	"github.com/Azure/azure-sdk-for-go/sdk/azidentity"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/containerservice/armcontainerservice/v4"
+
+	"github.com/stolostron/ai-test-gen/e2e/pkg/providers"
+)
+
+const readyTimeout = 30 * time.Minute
+
+// Options configures the AKS provider. The default Azure credential chain
+// (environment service principal, managed identity, CLI login) supplies
+// authentication.
+type Options struct {
+	SubscriptionID string
+	ResourceGroup  string
+	Location       string
+	// NodeCount sizes the default agent pool.
+	NodeCount int32
+	VMSize    string
+	// DNSPrefix defaults to the cluster name when empty.
+	DNSPrefix string
+}
+
+// Provider implements providers.Provider backed by the AKS API.
+type Provider struct {
+	options Options
+}
+
+var _ providers.Provider = &Provider{}
+
+// New returns an AKS provider for the given options.
+func New(options Options) *Provider {
+	return &Provider{options: options}
+}
+
+func (p *Provider) client() (*armcontainerservice.ManagedClustersClient, error) {
+	credential, err := azidentity.NewDefaultAzureCredential(nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load Azure credentials: %w", err)
+	}
+	client, err := armcontainerservice.NewManagedClustersClient(p.options.SubscriptionID, credential, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create AKS client: %w", err)
+	}
+	return client, nil
+}
+
+// Create provisions an AKS cluster with a system-assigned identity and one
+// agent pool, waits for provisioning to succeed and returns the admin
+// kubeconfig.
+func (p *Provider) Create(ctx context.Context, name string) (*providers.Cluster, error) {
+	client, err := p.client()
+	if err != nil {
+		return nil, err
+	}
+
+	nodeCount := p.options.NodeCount
+	if nodeCount == 0 {
+		nodeCount = 2
+	}
+	dnsPrefix := p.options.DNSPrefix
+	if dnsPrefix == "" {
+		dnsPrefix = name
+	}
+	poller, err := client.BeginCreateOrUpdate(ctx, p.options.ResourceGroup, name,
+		armcontainerservice.ManagedCluster{
+			Location: to.Ptr(p.options.Location),
+			Identity: &armcontainerservice.ManagedClusterIdentity{
+				Type: to.Ptr(armcontainerservice.ResourceIdentityTypeSystemAssigned),
+			},
+			Properties: &armcontainerservice.ManagedClusterProperties{
+				DNSPrefix: to.Ptr(dnsPrefix),
+				AgentPoolProfiles: []*armcontainerservice.ManagedClusterAgentPoolProfile{{
+					Name:   to.Ptr("workers"),
+					Count:  to.Ptr(nodeCount),
+					VMSize: to.Ptr(p.options.VMSize),
+					Mode:   to.Ptr(armcontainerservice.AgentPoolModeSystem),
+				}},
+			},
+		}, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create AKS cluster %s: %w", name, err)
+	}
+
+	pollCtx, cancel := context.WithTimeout(ctx, readyTimeout)
+	defer cancel()
+	result, err := poller.PollUntilDone(pollCtx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("AKS cluster %s did not finish provisioning: %w", name, err)
+	}
+
+	credentials, err := client.ListClusterAdminCredentials(ctx, p.options.ResourceGroup, name, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get admin credentials of AKS cluster %s: %w", name, err)
+	}
+	if len(credentials.Kubeconfigs) == 0 {
+		return nil, fmt.Errorf("AKS cluster %s returned no admin kubeconfig", name)
+	}
+
+	endpoint := ""
+	if result.Properties != nil && result.Properties.Fqdn != nil {
+		endpoint = "https://" + *result.Properties.Fqdn
+	}
+	return &providers.Cluster{
+		Name:       name,
+		Endpoint:   endpoint,
+		Kubeconfig: credentials.Kubeconfigs[0].Value,
+	}, nil
+}
+
+// Delete removes the cluster; an absent cluster is ignored.
+func (p *Provider) Delete(ctx context.Context, name string) error {
+	client, err := p.client()
+	if err != nil {
+		return err
+	}
+	poller, err := client.BeginDelete(ctx, p.options.ResourceGroup, name, nil)
+	if err != nil {
+		if isNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to delete AKS cluster %s: %w", name, err)
+	}
+	pollCtx, cancel := context.WithTimeout(ctx, readyTimeout)
+	defer cancel()
+	if _, err := poller.PollUntilDone(pollCtx, nil); err != nil {
+		return fmt.Errorf("AKS cluster %s did not finish deleting: %w", name, err)
+	}
+	return nil
+}
+
+func isNotFound(err error) bool {
+	var responseErr *azcore.ResponseError
+	return errors.As(err, &responseErr) && responseErr.StatusCode == http.StatusNotFound
+}
@@ -0,0 +1,247 @@
+// Copyright Contributors to the Open Cluster Management project
+
+// Package eks provisions Amazon EKS clusters through the AWS SDK as import
+// prerequisites for the e2e suites.
+package eks
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/eks"
+	"github.com/aws/aws-sdk-go-v2/service/eks/types"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+
+	"github.com/stolostron/ai-test-gen/e2e/pkg/providers"
+)
+
+const (
+	pollInterval = 30 * time.Second
+	readyTimeout = 30 * time.Minute
+)
+
+// Options configures the EKS provider. The standard AWS credential chain
+// (environment, shared config, IAM role) supplies the credentials.
+type Options struct {
+	Region string
+	// Version is the Kubernetes minor version, for example "1.29".
+	Version string
+	// RoleARN is the cluster IAM role; NodeRoleARN the node group role.
+	RoleARN     string
+	NodeRoleARN string
+	SubnetIDs   []string
+	// NodeCount sizes the default node group.
+	NodeCount    int32
+	InstanceType string
+}
+
+// Provider implements providers.Provider backed by the EKS API.
+type Provider struct {
+	options Options
+}
+
+var _ providers.Provider = &Provider{}
+
+// New returns an EKS provider for the given options.
+func New(options Options) *Provider {
+	return &Provider{options: options}
+}
+
+func (p *Provider) client(ctx context.Context) (*eks.Client, error) {
+	cfg, err := config.LoadDefaultConfig(ctx, config.WithRegion(p.options.Region))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load AWS configuration: %w", err)
+	}
+	return eks.NewFromConfig(cfg), nil
+}
+
+// Create provisions an EKS cluster with one managed node group and waits
+// until both are active.
+func (p *Provider) Create(ctx context.Context, name string) (*providers.Cluster, error) {
+	client, err := p.client(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = client.CreateCluster(ctx, &eks.CreateClusterInput{
+		Name:    aws.String(name),
+		Version: aws.String(p.options.Version),
+		RoleArn: aws.String(p.options.RoleARN),
+		ResourcesVpcConfig: &types.VpcConfigRequest{
+			SubnetIds: p.options.SubnetIDs,
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create EKS cluster %s: %w", name, err)
+	}
+	cluster, err := p.waitClusterActive(ctx, client, name)
+	if err != nil {
+		return nil, err
+	}
+
+	nodeCount := p.options.NodeCount
+	if nodeCount == 0 {
+		nodeCount = 2
+	}
+	_, err = client.CreateNodegroup(ctx, &eks.CreateNodegroupInput{
+		ClusterName:   aws.String(name),
+		NodegroupName: aws.String(name + "-workers"),
+		NodeRole:      aws.String(p.options.NodeRoleARN),
+		Subnets:       p.options.SubnetIDs,
+		InstanceTypes: []string{p.options.InstanceType},
+		ScalingConfig: &types.NodegroupScalingConfig{
+			DesiredSize: aws.Int32(nodeCount),
+			MinSize:     aws.Int32(nodeCount),
+			MaxSize:     aws.Int32(nodeCount),
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create node group for EKS cluster %s: %w", name, err)
+	}
+	if err := p.waitNodegroupActive(ctx, client, name, name+"-workers"); err != nil {
+		return nil, err
+	}
+
+	kubeconfig, err := buildKubeconfig(name, cluster)
+	if err != nil {
+		return nil, err
+	}
+	return &providers.Cluster{
+		Name:       name,
+		Endpoint:   aws.ToString(cluster.Endpoint),
+		Kubeconfig: kubeconfig,
+	}, nil
+}
+
+func (p *Provider) waitClusterActive(ctx context.Context, client *eks.Client, name string) (*types.Cluster, error) {
+	deadline := time.Now().Add(readyTimeout)
+	for {
+		out, err := client.DescribeCluster(ctx, &eks.DescribeClusterInput{Name: aws.String(name)})
+		if err != nil {
+			return nil, fmt.Errorf("failed to poll EKS cluster %s: %w", name, err)
+		}
+		switch out.Cluster.Status {
+		case types.ClusterStatusActive:
+			return out.Cluster, nil
+		case types.ClusterStatusFailed:
+			return nil, fmt.Errorf("EKS cluster %s entered FAILED state", name)
+		}
+		if time.Now().After(deadline) {
+			return nil, fmt.Errorf("EKS cluster %s still %s after %s", name, out.Cluster.Status, readyTimeout)
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(pollInterval):
+		}
+	}
+}
+
+func (p *Provider) waitNodegroupActive(ctx context.Context, client *eks.Client, clusterName, nodegroupName string) error {
+	deadline := time.Now().Add(readyTimeout)
+	for {
+		out, err := client.DescribeNodegroup(ctx, &eks.DescribeNodegroupInput{
+			ClusterName:   aws.String(clusterName),
+			NodegroupName: aws.String(nodegroupName),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to poll node group %s of EKS cluster %s: %w", nodegroupName, clusterName, err)
+		}
+		switch out.Nodegroup.Status {
+		case types.NodegroupStatusActive:
+			return nil
+		case types.NodegroupStatusCreateFailed, types.NodegroupStatusDegraded:
+			return fmt.Errorf("node group %s of EKS cluster %s entered %s state", nodegroupName, clusterName, out.Nodegroup.Status)
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("node group %s of EKS cluster %s still %s after %s", nodegroupName, clusterName, out.Nodegroup.Status, readyTimeout)
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(pollInterval):
+		}
+	}
+}
+
+// buildKubeconfig renders a kubeconfig that authenticates through the
+// standard "aws eks get-token" exec plugin.
+func buildKubeconfig(name string, cluster *types.Cluster) ([]byte, error) {
+	ca, err := base64.StdEncoding.DecodeString(aws.ToString(cluster.CertificateAuthority.Data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode CA of EKS cluster %s: %w", name, err)
+	}
+	kubeconfig := clientcmdapi.NewConfig()
+	kubeconfig.Clusters[name] = &clientcmdapi.Cluster{
+		Server:                   aws.ToString(cluster.Endpoint),
+		CertificateAuthorityData: ca,
+	}
+	kubeconfig.AuthInfos[name] = &clientcmdapi.AuthInfo{
+		Exec: &clientcmdapi.ExecConfig{
+			APIVersion: "client.authentication.k8s.io/v1beta1",
+			Command:    "aws",
+			Args:       []string{"eks", "get-token", "--cluster-name", name},
+		},
+	}
+	kubeconfig.Contexts[name] = &clientcmdapi.Context{Cluster: name, AuthInfo: name}
+	kubeconfig.CurrentContext = name
+	return clientcmd.Write(*kubeconfig)
+}
+
+// Delete removes the node group and cluster; absent resources are ignored.
+func (p *Provider) Delete(ctx context.Context, name string) error {
+	client, err := p.client(ctx)
+	if err != nil {
+		return err
+	}
+
+	_, err = client.DeleteNodegroup(ctx, &eks.DeleteNodegroupInput{
+		ClusterName:   aws.String(name),
+		NodegroupName: aws.String(name + "-workers"),
+	})
+	if err != nil && !isNotFound(err) {
+		return fmt.Errorf("failed to delete node group of EKS cluster %s: %w", name, err)
+	}
+
+	deadline := time.Now().Add(readyTimeout)
+	for {
+		_, err := client.DescribeNodegroup(ctx, &eks.DescribeNodegroupInput{
+			ClusterName:   aws.String(name),
+			NodegroupName: aws.String(name + "-workers"),
+		})
+		if isNotFound(err) {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("failed to poll node group deletion of EKS cluster %s: %w", name, err)
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("node group of EKS cluster %s not deleted after %s", name, readyTimeout)
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(pollInterval):
+		}
+	}
+
+	_, err = client.DeleteCluster(ctx, &eks.DeleteClusterInput{Name: aws.String(name)})
+	if err != nil && !isNotFound(err) {
+		return fmt.Errorf("failed to delete EKS cluster %s: %w", name, err)
+	}
+	return nil
+}
+
+func isNotFound(err error) bool {
+	if err == nil {
+		return false
+	}
+	var notFound *types.ResourceNotFoundException
+	return errors.As(err, &notFound)
+}
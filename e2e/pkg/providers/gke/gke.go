@@ -0,0 +1,170 @@
+// Copyright Contributors to the Open Cluster Management project
+
+// Package gke provisions Google Kubernetes Engine clusters through the
+// Google Cloud SDK as import prerequisites for the e2e suites.
+package gke
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"time"
+
+	container "cloud.google.com/go/container/apiv1"
+	containerpb "cloud.google.com/go/container/apiv1/containerpb"
+	"golang.org/x/oauth2/google"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+
+	"github.com/stolostron/ai-test-gen/e2e/pkg/providers"
+)
+
+const (
+	pollInterval = 30 * time.Second
+	readyTimeout = 30 * time.Minute
+)
+
+// Options configures the GKE provider. Application default credentials
+// supply authentication.
+type Options struct {
+	ProjectID string
+	// Location is a zone or region, for example "us-central1-a".
+	Location string
+	// NodeCount sizes the default node pool.
+	NodeCount   int32
+	MachineType string
+}
+
+// Provider implements providers.Provider backed by the GKE API.
+type Provider struct {
+	options Options
+}
+
+var _ providers.Provider = &Provider{}
+
+// New returns a GKE provider for the given options.
+func New(options Options) *Provider {
+	return &Provider{options: options}
+}
+
+func (p *Provider) parent() string {
+	return fmt.Sprintf("projects/%s/locations/%s", p.options.ProjectID, p.options.Location)
+}
+
+func (p *Provider) clusterPath(name string) string {
+	return fmt.Sprintf("%s/clusters/%s", p.parent(), name)
+}
+
+// Create provisions a GKE cluster and waits until it is running.
+func (p *Provider) Create(ctx context.Context, name string) (*providers.Cluster, error) {
+	client, err := container.NewClusterManagerClient(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create GKE client: %w", err)
+	}
+	defer client.Close()
+
+	nodeCount := p.options.NodeCount
+	if nodeCount == 0 {
+		nodeCount = 2
+	}
+	_, err = client.CreateCluster(ctx, &containerpb.CreateClusterRequest{
+		Parent: p.parent(),
+		Cluster: &containerpb.Cluster{
+			Name:             name,
+			InitialNodeCount: nodeCount,
+			NodeConfig: &containerpb.NodeConfig{
+				MachineType: p.options.MachineType,
+			},
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create GKE cluster %s: %w", name, err)
+	}
+
+	cluster, err := p.waitRunning(ctx, client, name)
+	if err != nil {
+		return nil, err
+	}
+	kubeconfig, err := p.buildKubeconfig(ctx, name, cluster)
+	if err != nil {
+		return nil, err
+	}
+	return &providers.Cluster{
+		Name:       name,
+		Endpoint:   "https://" + cluster.Endpoint,
+		Kubeconfig: kubeconfig,
+	}, nil
+}
+
+func (p *Provider) waitRunning(ctx context.Context, client *container.ClusterManagerClient, name string) (*containerpb.Cluster, error) {
+	deadline := time.Now().Add(readyTimeout)
+	for {
+		cluster, err := client.GetCluster(ctx, &containerpb.GetClusterRequest{Name: p.clusterPath(name)})
+		if err != nil {
+			return nil, fmt.Errorf("failed to poll GKE cluster %s: %w", name, err)
+		}
+		switch cluster.Status {
+		case containerpb.Cluster_RUNNING:
+			return cluster, nil
+		case containerpb.Cluster_ERROR, containerpb.Cluster_DEGRADED:
+			return nil, fmt.Errorf("GKE cluster %s entered %s state: %s", name, cluster.Status, cluster.StatusMessage)
+		}
+		if time.Now().After(deadline) {
+			return nil, fmt.Errorf("GKE cluster %s still %s after %s", name, cluster.Status, readyTimeout)
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(pollInterval):
+		}
+	}
+}
+
+// buildKubeconfig renders a kubeconfig that authenticates with a short-lived
+// access token from the application default credentials, matching what the
+// auto-import secret consumes.
+func (p *Provider) buildKubeconfig(ctx context.Context, name string, cluster *containerpb.Cluster) ([]byte, error) {
+	ca, err := base64.StdEncoding.DecodeString(cluster.MasterAuth.ClusterCaCertificate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode CA of GKE cluster %s: %w", name, err)
+	}
+	credentials, err := google.FindDefaultCredentials(ctx, container.DefaultAuthScopes()...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load Google credentials: %w", err)
+	}
+	token, err := credentials.TokenSource.Token()
+	if err != nil {
+		return nil, fmt.Errorf("failed to mint Google access token: %w", err)
+	}
+
+	kubeconfig := clientcmdapi.NewConfig()
+	kubeconfig.Clusters[name] = &clientcmdapi.Cluster{
+		Server:                   "https://" + cluster.Endpoint,
+		CertificateAuthorityData: ca,
+	}
+	kubeconfig.AuthInfos[name] = &clientcmdapi.AuthInfo{Token: token.AccessToken}
+	kubeconfig.Contexts[name] = &clientcmdapi.Context{Cluster: name, AuthInfo: name}
+	kubeconfig.CurrentContext = name
+	return clientcmd.Write(*kubeconfig)
+}
+
+// Delete removes the cluster; an absent cluster is ignored.
+func (p *Provider) Delete(ctx context.Context, name string) error {
+	client, err := container.NewClusterManagerClient(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to create GKE client: %w", err)
+	}
+	defer client.Close()
+
+	_, err = client.DeleteCluster(ctx, &containerpb.DeleteClusterRequest{Name: p.clusterPath(name)})
+	if err != nil && !isNotFound(err) {
+		return fmt.Errorf("failed to delete GKE cluster %s: %w", name, err)
+	}
+	return nil
+}
+
+func isNotFound(err error) bool {
+	return err != nil && status.Code(err) == codes.NotFound
+}
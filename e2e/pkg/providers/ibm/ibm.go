@@ -0,0 +1,193 @@
+// Copyright Contributors to the Open Cluster Management project
+
+// Package ibm provisions IBM Cloud Kubernetes Service (IKS) and Red Hat
+// OpenShift on IBM Cloud (ROKS) clusters as import prerequisites for the
+// e2e suites.
+package ibm
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	bluemix "github.com/IBM-Cloud/bluemix-go"
+	"github.com/IBM-Cloud/bluemix-go/api/container/containerv2"
+	"github.com/IBM-Cloud/bluemix-go/session"
+
+	"github.com/stolostron/ai-test-gen/e2e/pkg/providers"
+)
+
+const (
+	pollInterval = 30 * time.Second
+	readyTimeout = 60 * time.Minute
+)
+
+// Platform selects the cluster flavor to provision.
+type Platform string
+
+const (
+	// IKS provisions a vanilla Kubernetes cluster.
+	IKS Platform = "iks"
+	// ROKS provisions a Red Hat OpenShift on IBM Cloud cluster.
+	ROKS Platform = "roks"
+)
+
+// Options configures the IBM Cloud provider.
+type Options struct {
+	// APIKey is the IBM Cloud IAM API key.
+	APIKey string
+	Region string
+	// Platform selects IKS or ROKS.
+	Platform Platform
+	// KubeVersion pins the version; for ROKS it must carry the
+	// "_openshift" suffix, which is appended automatically when missing.
+	KubeVersion string
+	// VPCID, SubnetID and ResourceGroupID locate the VPC Gen2 resources.
+	VPCID           string
+	SubnetID        string
+	ResourceGroupID string
+	Zone            string
+	WorkerCount     int
+	Flavor          string
+}
+
+// Provider implements providers.Provider backed by the IBM Cloud container
+// API.
+type Provider struct {
+	options Options
+}
+
+var _ providers.Provider = &Provider{}
+
+// New returns an IBM Cloud provider for the given options.
+func New(options Options) *Provider {
+	return &Provider{options: options}
+}
+
+func (p *Provider) clusterAPI() (containerv2.Clusters, containerv2.ContainerServiceAPI, error) {
+	sess, err := session.New(&bluemix.Config{
+		BluemixAPIKey: p.options.APIKey,
+		Region:        p.options.Region,
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create IBM Cloud session: %w", err)
+	}
+	api, err := containerv2.New(sess)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create IBM Cloud container client: %w", err)
+	}
+	return api.Clusters(), api, nil
+}
+
+func (p *Provider) target() containerv2.ClusterTargetHeader {
+	return containerv2.ClusterTargetHeader{ResourceGroup: p.options.ResourceGroupID}
+}
+
+// kubeVersion normalizes the version for the selected platform.
+func (p *Provider) kubeVersion() string {
+	version := p.options.KubeVersion
+	if p.options.Platform == ROKS && !strings.HasSuffix(version, "_openshift") {
+		version += "_openshift"
+	}
+	return version
+}
+
+// Create provisions an IKS or ROKS cluster on VPC Gen2 infrastructure and
+// waits until it is in normal state.
+func (p *Provider) Create(ctx context.Context, name string) (*providers.Cluster, error) {
+	clusters, _, err := p.clusterAPI()
+	if err != nil {
+		return nil, err
+	}
+
+	workerCount := p.options.WorkerCount
+	if workerCount == 0 {
+		workerCount = 2
+	}
+	_, err = clusters.Create(containerv2.ClusterCreateRequest{
+		Name:        name,
+		Provider:    "vpc-gen2",
+		KubeVersion: p.kubeVersion(),
+		WorkerPools: containerv2.WorkerPoolConfig{
+			CommonWorkerPoolConfig: containerv2.CommonWorkerPoolConfig{
+				Name:        "default",
+				Flavor:      p.options.Flavor,
+				VpcID:       p.options.VPCID,
+				WorkerCount: workerCount,
+				Zones: []containerv2.Zone{{
+					ID:       p.options.Zone,
+					SubnetID: p.options.SubnetID,
+				}},
+			},
+		},
+	}, p.target())
+	if err != nil {
+		return nil, fmt.Errorf("failed to create %s cluster %s: %w", p.options.Platform, name, err)
+	}
+
+	cluster, err := p.waitNormal(ctx, clusters, name)
+	if err != nil {
+		return nil, err
+	}
+	// The container API only hands the kubeconfig out as files on disk;
+	// download into a scratch directory and read it back.
+	configDir, err := os.MkdirTemp("", "ibm-kubeconfig-")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create kubeconfig directory: %w", err)
+	}
+	defer os.RemoveAll(configDir)
+	keyInfo, err := clusters.GetClusterConfigDetail(name, configDir, true, p.target(), "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get kubeconfig of %s cluster %s: %w", p.options.Platform, name, err)
+	}
+	kubeconfig, err := os.ReadFile(keyInfo.FilePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read kubeconfig of %s cluster %s: %w", p.options.Platform, name, err)
+	}
+	return &providers.Cluster{
+		Name:       name,
+		Endpoint:   cluster.ServiceEndpoints.PublicServiceEndpointURL,
+		Kubeconfig: kubeconfig,
+	}, nil
+}
+
+func (p *Provider) waitNormal(ctx context.Context, clusters containerv2.Clusters, name string) (*containerv2.ClusterInfo, error) {
+	deadline := time.Now().Add(readyTimeout)
+	for {
+		cluster, err := clusters.GetCluster(name, p.target())
+		if err != nil {
+			return nil, fmt.Errorf("failed to poll %s cluster %s: %w", p.options.Platform, name, err)
+		}
+		switch cluster.State {
+		case "normal":
+			return cluster, nil
+		case "critical", "delete_failed":
+			return nil, fmt.Errorf("%s cluster %s entered %s state", p.options.Platform, name, cluster.State)
+		}
+		if time.Now().After(deadline) {
+			return nil, fmt.Errorf("%s cluster %s still %s after %s", p.options.Platform, name, cluster.State, readyTimeout)
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(pollInterval):
+		}
+	}
+}
+
+// Delete removes the cluster and its storage; an absent cluster is ignored.
+func (p *Provider) Delete(ctx context.Context, name string) error {
+	clusters, _, err := p.clusterAPI()
+	if err != nil {
+		return err
+	}
+	if err := clusters.Delete(name, p.target(), true); err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			return nil
+		}
+		return fmt.Errorf("failed to delete %s cluster %s: %w", p.options.Platform, name, err)
+	}
+	return nil
+}
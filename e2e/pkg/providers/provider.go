@@ -0,0 +1,31 @@
+// Copyright Contributors to the Open Cluster Management project
+
+// Package providers provisions managed clusters on external services (OCM,
+// EKS, GKE, AKS, IBM Cloud) as import prerequisites for the e2e suites. The
+// framework only needs a running cluster and a kubeconfig; everything after
+// that goes through the hub import helpers.
+package providers
+
+import "context"
+
+// Cluster is the provisioning result handed to the import helpers.
+type Cluster struct {
+	// Name is the provider-side cluster name.
+	Name string
+	// Endpoint is the API server URL.
+	Endpoint string
+	// Kubeconfig grants admin access and is consumed by the auto-import
+	// secret; it is never written to disk by the providers.
+	Kubeconfig []byte
+}
+
+// Provider creates and destroys clusters on one external service.
+// Implementations are expected to be idempotent on Delete and to return a
+// ready-to-use cluster from Create, including any provider-side waiting.
+type Provider interface {
+	// Create provisions a cluster and blocks until it is reachable.
+	Create(ctx context.Context, name string) (*Cluster, error)
+	// Delete deprovisions a cluster; deleting an absent cluster is not an
+	// error.
+	Delete(ctx context.Context, name string) error
+}
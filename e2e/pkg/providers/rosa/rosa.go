@@ -0,0 +1,180 @@
+// Copyright Contributors to the Open Cluster Management project
+
+// Package rosa provisions ROSA hosted control plane (HCP) clusters through
+// the OCM clusters_mgmt API, so import suites can run against ROSA HCP
+// without shelling out to the rosa CLI.
+package rosa
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	sdk "github.com/openshift-online/ocm-sdk-go"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+
+	"github.com/stolostron/ai-test-gen/e2e/pkg/providers"
+)
+
+const (
+	pollInterval = 30 * time.Second
+	readyTimeout = 45 * time.Minute
+)
+
+// Options configures the ROSA HCP provider.
+type Options struct {
+	// Token is the OCM offline token or service account token.
+	Token string
+	// URL overrides the OCM API gateway; empty selects production.
+	URL     string
+	Region  string
+	Version string
+	// AWS wiring required for hosted control planes.
+	AccountID      string
+	SubnetIDs      []string
+	OidcConfigID   string
+	OperatorPrefix string
+	// BillingAccountID defaults to AccountID when empty.
+	BillingAccountID string
+}
+
+// Provider implements providers.Provider backed by the OCM API.
+type Provider struct {
+	options Options
+}
+
+var _ providers.Provider = &Provider{}
+
+// New returns a ROSA HCP provider for the given options.
+func New(options Options) *Provider {
+	return &Provider{options: options}
+}
+
+// connect builds an authenticated OCM connection; callers must Close it.
+func (p *Provider) connect() (*sdk.Connection, error) {
+	builder := sdk.NewConnectionBuilder().Tokens(p.options.Token)
+	if p.options.URL != "" {
+		builder = builder.URL(p.options.URL)
+	}
+	connection, err := builder.Build()
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to OCM: %w", err)
+	}
+	return connection, nil
+}
+
+// Create provisions a ROSA HCP cluster and waits for it to become ready.
+func (p *Provider) Create(ctx context.Context, name string) (*providers.Cluster, error) {
+	connection, err := p.connect()
+	if err != nil {
+		return nil, err
+	}
+	defer connection.Close()
+
+	billing := p.options.BillingAccountID
+	if billing == "" {
+		billing = p.options.AccountID
+	}
+	spec, err := cmv1.NewCluster().
+		Name(name).
+		Product(cmv1.NewProduct().ID("rosa")).
+		Region(cmv1.NewCloudRegion().ID(p.options.Region)).
+		Version(cmv1.NewVersion().ID(p.options.Version)).
+		Hypershift(cmv1.NewHypershift().Enabled(true)).
+		AWS(cmv1.NewAWS().
+			AccountID(p.options.AccountID).
+			BillingAccountID(billing).
+			SubnetIDs(p.options.SubnetIDs...).
+			STS(cmv1.NewSTS().
+				OidcConfig(cmv1.NewOidcConfig().ID(p.options.OidcConfigID)).
+				OperatorRolePrefix(p.options.OperatorPrefix))).
+		Build()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build ROSA HCP cluster spec for %s: %w", name, err)
+	}
+
+	clusters := connection.ClustersMgmt().V1().Clusters()
+	response, err := clusters.Add().Body(spec).SendContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create ROSA HCP cluster %s: %w", name, err)
+	}
+	clusterID := response.Body().ID()
+
+	if err := p.waitReady(ctx, connection, clusterID); err != nil {
+		return nil, err
+	}
+
+	kubeconfig, err := p.adminKubeconfig(ctx, connection, clusterID)
+	if err != nil {
+		return nil, err
+	}
+	cluster, err := clusters.Cluster(clusterID).Get().SendContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get ROSA HCP cluster %s: %w", name, err)
+	}
+	return &providers.Cluster{
+		Name:       name,
+		Endpoint:   cluster.Body().API().URL(),
+		Kubeconfig: kubeconfig,
+	}, nil
+}
+
+// waitReady polls the cluster state until it reports ready.
+func (p *Provider) waitReady(ctx context.Context, connection *sdk.Connection, clusterID string) error {
+	deadline := time.Now().Add(readyTimeout)
+	for {
+		response, err := connection.ClustersMgmt().V1().Clusters().Cluster(clusterID).Get().SendContext(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to poll ROSA HCP cluster %s: %w", clusterID, err)
+		}
+		switch state := response.Body().State(); state {
+		case cmv1.ClusterStateReady:
+			return nil
+		case cmv1.ClusterStateError:
+			return fmt.Errorf("ROSA HCP cluster %s entered error state", clusterID)
+		default:
+			if time.Now().After(deadline) {
+				return fmt.Errorf("ROSA HCP cluster %s still %s after %s", clusterID, state, readyTimeout)
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(pollInterval):
+		}
+	}
+}
+
+// adminKubeconfig creates a cluster-admin credential and returns its
+// kubeconfig.
+func (p *Provider) adminKubeconfig(ctx context.Context, connection *sdk.Connection, clusterID string) ([]byte, error) {
+	credentials, err := connection.ClustersMgmt().V1().Clusters().Cluster(clusterID).Credentials().Get().SendContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get credentials of ROSA HCP cluster %s: %w", clusterID, err)
+	}
+	return []byte(credentials.Body().Kubeconfig()), nil
+}
+
+// Delete deprovisions the named cluster; unknown clusters are ignored.
+func (p *Provider) Delete(ctx context.Context, name string) error {
+	connection, err := p.connect()
+	if err != nil {
+		return err
+	}
+	defer connection.Close()
+
+	clusters := connection.ClustersMgmt().V1().Clusters()
+	search := fmt.Sprintf("name = '%s'", name)
+	list, err := clusters.List().Search(search).Size(1).SendContext(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to look up ROSA HCP cluster %s: %w", name, err)
+	}
+	if list.Items().Len() == 0 {
+		return nil
+	}
+	clusterID := list.Items().Get(0).ID()
+	if _, err := clusters.Cluster(clusterID).Delete().SendContext(ctx); err != nil {
+		return fmt.Errorf("failed to delete ROSA HCP cluster %s: %w", name, err)
+	}
+	return nil
+}
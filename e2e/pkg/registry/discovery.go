@@ -0,0 +1,151 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package registry
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+// discoveryProbeTimeout bounds the per-cluster version probe so one dead
+// kubeconfig does not stall suite start.
+const discoveryProbeTimeout = 20 * time.Second
+
+// Discover builds a registry by scanning a directory for kubeconfig
+// files, probing each cluster for its type and version. It supersedes
+// suffix-glob lookup: tests then ask for "an eks cluster at least 1.28"
+// through Select instead of matching filenames. Unreachable clusters are
+// included without version metadata so callers can still select them
+// explicitly by name.
+func Discover(ctx context.Context, dir string) (*Registry, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan kubeconfig directory %s: %w", dir, err)
+	}
+
+	registry := &Registry{}
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		path := filepath.Join(dir, entry.Name())
+		raw, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", path, err)
+		}
+		clientConfig, err := clientcmd.RESTConfigFromKubeConfig(raw)
+		if err != nil {
+			// Not a kubeconfig; directories often hold READMEs and certs.
+			continue
+		}
+
+		cluster := Cluster{
+			Name:           strings.TrimSuffix(entry.Name(), filepath.Ext(entry.Name())),
+			Type:           clusterTypeFromEndpoint(clientConfig.Host),
+			KubeconfigPath: path,
+			Labels:         map[string]string{},
+		}
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		if version, err := probeClusterVersion(path); err == nil {
+			cluster.Labels["version"] = version
+		}
+		registry.Clusters = append(registry.Clusters, cluster)
+	}
+	if len(registry.Clusters) == 0 {
+		return nil, fmt.Errorf("no kubeconfigs found under %s", dir)
+	}
+	return registry, nil
+}
+
+// clusterTypeFromEndpoint infers the managed-service flavor from the API
+// server hostname; anything unrecognized is assumed to be OCP, the
+// common case in our labs.
+func clusterTypeFromEndpoint(host string) string {
+	lowered := strings.ToLower(host)
+	switch {
+	case strings.Contains(lowered, ".eks.amazonaws.com"):
+		return "eks"
+	case strings.Contains(lowered, ".gke.goog") || strings.Contains(lowered, "container.googleapis.com"):
+		return "gke"
+	case strings.Contains(lowered, ".azmk8s.io"):
+		return "aks"
+	case strings.Contains(lowered, ".containers.cloud.ibm.com"):
+		return "iks"
+	case strings.Contains(lowered, ".openshiftapps.com"):
+		return "rosa-hcp"
+	default:
+		return "ocp"
+	}
+}
+
+// probeClusterVersion asks the cluster for its server version, returned
+// as "major.minor".
+func probeClusterVersion(kubeconfigPath string) (string, error) {
+	config, err := clientcmd.BuildConfigFromFlags("", kubeconfigPath)
+	if err != nil {
+		return "", err
+	}
+	config.Timeout = discoveryProbeTimeout
+	discoveryClient, err := discovery.NewDiscoveryClientForConfig(config)
+	if err != nil {
+		return "", err
+	}
+	version, err := discoveryClient.ServerVersion()
+	if err != nil {
+		return "", err
+	}
+	minor := strings.TrimRight(version.Minor, "+")
+	return version.Major + "." + minor, nil
+}
+
+// SelectAtLeast returns the clusters matching the query whose probed
+// version is at least minVersion ("1.28"). Clusters without version
+// metadata never match — an unprobed cluster cannot satisfy a version
+// constraint.
+func (r *Registry) SelectAtLeast(query Query, minVersion string) ([]*Cluster, error) {
+	wantMajor, wantMinor, err := parseMajorMinor(minVersion)
+	if err != nil {
+		return nil, fmt.Errorf("invalid minimum version %q: %w", minVersion, err)
+	}
+	var matched []*Cluster
+	for _, cluster := range r.Select(query) {
+		version := cluster.Labels["version"]
+		if version == "" {
+			continue
+		}
+		major, minor, err := parseMajorMinor(version)
+		if err != nil {
+			continue
+		}
+		if major > wantMajor || (major == wantMajor && minor >= wantMinor) {
+			matched = append(matched, cluster)
+		}
+	}
+	return matched, nil
+}
+
+func parseMajorMinor(version string) (int, int, error) {
+	parts := strings.SplitN(strings.TrimPrefix(version, "v"), ".", 3)
+	if len(parts) < 2 {
+		return 0, 0, fmt.Errorf("expected major.minor")
+	}
+	major, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return 0, 0, err
+	}
+	minor, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return 0, 0, err
+	}
+	return major, minor, nil
+}
@@ -0,0 +1,94 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package registry
+
+import "testing"
+
+func TestClusterTypeFromEndpoint(t *testing.T) {
+	cases := map[string]string{
+		"https://ABC123.gr7.us-east-1.eks.amazonaws.com":       "eks",
+		"https://container.googleapis.com/v1/projects/x":       "gke",
+		"https://gke-1234.private.gke.goog":                    "gke",
+		"https://myaks-dns-abc.hcp.eastus.azmk8s.io:443":       "aks",
+		"https://c100.us-south.containers.cloud.ibm.com:30123": "iks",
+		"https://api.rosa-hcp1.abcd.p3.openshiftapps.com:443":  "rosa-hcp",
+		"https://api.clc-hub.dev09.red-chesterfield.com:6443":  "ocp",
+		"https://API.CLC-HUB.DEV09.EKS.AMAZONAWS.COM":          "eks",
+	}
+	for host, want := range cases {
+		if got := clusterTypeFromEndpoint(host); got != want {
+			t.Errorf("clusterTypeFromEndpoint(%q) = %q, want %q", host, got, want)
+		}
+	}
+}
+
+func TestParseMajorMinor(t *testing.T) {
+	cases := []struct {
+		version      string
+		major, minor int
+		wantErr      bool
+	}{
+		{version: "1.28", major: 1, minor: 28},
+		{version: "v1.30", major: 1, minor: 30},
+		{version: "1.29.7", major: 1, minor: 29},
+		{version: "1", wantErr: true},
+		{version: "one.two", wantErr: true},
+		{version: "", wantErr: true},
+	}
+	for _, testcase := range cases {
+		major, minor, err := parseMajorMinor(testcase.version)
+		if testcase.wantErr {
+			if err == nil {
+				t.Errorf("parseMajorMinor(%q) accepted", testcase.version)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("parseMajorMinor(%q): %v", testcase.version, err)
+			continue
+		}
+		if major != testcase.major || minor != testcase.minor {
+			t.Errorf("parseMajorMinor(%q) = %d.%d, want %d.%d",
+				testcase.version, major, minor, testcase.major, testcase.minor)
+		}
+	}
+}
+
+func versionedCluster(name, clusterType, version string) Cluster {
+	labels := map[string]string{}
+	if version != "" {
+		labels["version"] = version
+	}
+	return Cluster{Name: name, Type: clusterType, Labels: labels}
+}
+
+func TestSelectAtLeast(t *testing.T) {
+	registry := &Registry{Clusters: []Cluster{
+		versionedCluster("old-eks", "eks", "1.27"),
+		versionedCluster("new-eks", "eks", "1.30"),
+		versionedCluster("unprobed-eks", "eks", ""),
+		versionedCluster("new-gke", "gke", "1.30"),
+	}}
+
+	matched, err := registry.SelectAtLeast(Query{Type: "eks"}, "1.28")
+	if err != nil {
+		t.Fatalf("SelectAtLeast: %v", err)
+	}
+	if len(matched) != 1 || matched[0].Name != "new-eks" {
+		t.Errorf("matched = %+v, want only new-eks", matched)
+	}
+
+	// A higher major always satisfies a lower minor constraint.
+	registry.Clusters = append(registry.Clusters, versionedCluster("future", "eks", "2.0"))
+	matched, err = registry.SelectAtLeast(Query{Type: "eks"}, "1.99")
+	if err != nil {
+		t.Fatalf("SelectAtLeast: %v", err)
+	}
+	if len(matched) != 1 || matched[0].Name != "future" {
+		t.Errorf("matched = %+v, want only future", matched)
+	}
+
+	if _, err := registry.SelectAtLeast(Query{}, "nonsense"); err == nil {
+		t.Error("invalid minimum version was accepted")
+	}
+}
@@ -0,0 +1,174 @@
+// Copyright Contributors to the Open Cluster Management project
+
+// Package registry provides a structured inventory of the clusters
+// available to a test run. It replaces the old convention of discovering
+// kubeconfigs by filename globs (for example "*-eks.kubeconfig"): each
+// cluster is described with its type, credentials, capabilities and
+// ownership, and selected through a typed query instead of string
+// matching.
+package registry
+
+import (
+	"fmt"
+	"os"
+
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+	"sigs.k8s.io/yaml"
+)
+
+// Cluster describes one cluster available to the suites.
+type Cluster struct {
+	// Name is the registry-unique cluster name.
+	Name string `json:"name"`
+	// Type is the cluster flavor: ocp, eks, gke, aks, iks, roks, rosa-hcp.
+	Type string `json:"type"`
+	// KubeconfigPath points at the admin kubeconfig on disk. Registries
+	// may instead inline the kubeconfig through Kubeconfig.
+	KubeconfigPath string `json:"kubeconfigPath,omitempty"`
+	// Kubeconfig optionally inlines the kubeconfig content.
+	Kubeconfig string `json:"kubeconfig,omitempty"`
+	// Capabilities lists what the cluster may be used for, for example
+	// "import", "detach", "upgrade", "destructive".
+	Capabilities []string `json:"capabilities,omitempty"`
+	// Owner identifies the team or pipeline responsible for the cluster.
+	Owner string `json:"owner,omitempty"`
+	// Labels carries free-form metadata (region, version, network type).
+	Labels map[string]string `json:"labels,omitempty"`
+}
+
+// HasCapability reports whether the cluster declares a capability.
+func (c *Cluster) HasCapability(capability string) bool {
+	for _, have := range c.Capabilities {
+		if have == capability {
+			return true
+		}
+	}
+	return false
+}
+
+// RawKubeconfig returns the kubeconfig bytes, reading the path when the
+// content is not inlined.
+func (c *Cluster) RawKubeconfig() ([]byte, error) {
+	if c.Kubeconfig != "" {
+		return []byte(c.Kubeconfig), nil
+	}
+	if c.KubeconfigPath == "" {
+		return nil, fmt.Errorf("cluster %s has neither kubeconfig content nor a path", c.Name)
+	}
+	raw, err := os.ReadFile(c.KubeconfigPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read kubeconfig of cluster %s: %w", c.Name, err)
+	}
+	return raw, nil
+}
+
+// RESTConfig builds a rest.Config for the cluster.
+func (c *Cluster) RESTConfig() (*rest.Config, error) {
+	raw, err := c.RawKubeconfig()
+	if err != nil {
+		return nil, err
+	}
+	config, err := clientcmd.RESTConfigFromKubeConfig(raw)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse kubeconfig of cluster %s: %w", c.Name, err)
+	}
+	return config, nil
+}
+
+// Registry is the parsed cluster inventory.
+type Registry struct {
+	Clusters []Cluster `json:"clusters"`
+}
+
+// Load reads and validates a registry file.
+func Load(path string) (*Registry, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read cluster registry %s: %w", path, err)
+	}
+	registry := &Registry{}
+	if err := yaml.UnmarshalStrict(raw, registry); err != nil {
+		return nil, fmt.Errorf("failed to parse cluster registry %s: %w", path, err)
+	}
+
+	seen := map[string]bool{}
+	for i := range registry.Clusters {
+		cluster := &registry.Clusters[i]
+		if cluster.Name == "" {
+			return nil, fmt.Errorf("cluster registry %s: entry %d has no name", path, i)
+		}
+		if seen[cluster.Name] {
+			return nil, fmt.Errorf("cluster registry %s: duplicate cluster name %s", path, cluster.Name)
+		}
+		seen[cluster.Name] = true
+		if cluster.Type == "" {
+			return nil, fmt.Errorf("cluster registry %s: cluster %s has no type", path, cluster.Name)
+		}
+	}
+	return registry, nil
+}
+
+// Get returns the named cluster.
+func (r *Registry) Get(name string) (*Cluster, error) {
+	for i := range r.Clusters {
+		if r.Clusters[i].Name == name {
+			return &r.Clusters[i], nil
+		}
+	}
+	return nil, fmt.Errorf("cluster %s not found in registry", name)
+}
+
+// Query selects clusters by attributes; zero fields match everything.
+type Query struct {
+	Type       string
+	Capability string
+	Owner      string
+	// Labels must all be present with the given values.
+	Labels map[string]string
+}
+
+// Select returns all clusters matching the query.
+func (r *Registry) Select(query Query) []*Cluster {
+	var matched []*Cluster
+	for i := range r.Clusters {
+		cluster := &r.Clusters[i]
+		if query.Type != "" && cluster.Type != query.Type {
+			continue
+		}
+		if query.Capability != "" && !cluster.HasCapability(query.Capability) {
+			continue
+		}
+		if query.Owner != "" && cluster.Owner != query.Owner {
+			continue
+		}
+		if !labelsMatch(cluster.Labels, query.Labels) {
+			continue
+		}
+		matched = append(matched, cluster)
+	}
+	return matched
+}
+
+// SelectOne returns exactly one matching cluster and errors otherwise, for
+// suites that need a deterministic target.
+func (r *Registry) SelectOne(query Query) (*Cluster, error) {
+	matched := r.Select(query)
+	switch len(matched) {
+	case 0:
+		return nil, fmt.Errorf("no cluster in registry matches %+v", query)
+	case 1:
+		return matched[0], nil
+	default:
+		return nil, fmt.Errorf("%d clusters match %+v; narrow the query", len(matched), query)
+	}
+}
+
+func labelsMatch(have, want map[string]string) bool {
+	for k, v := range want {
+		if have[k] != v {
+			return false
+		}
+	}
+	return true
+}
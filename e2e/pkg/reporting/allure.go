@@ -0,0 +1,172 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package reporting
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/onsi/ginkgo/v2/types"
+)
+
+// Allure result model; one JSON file per spec in the results directory,
+// the layout `allure generate` consumes.
+type allureResult struct {
+	UUID          string             `json:"uuid"`
+	Name          string             `json:"name"`
+	FullName      string             `json:"fullName"`
+	Status        string             `json:"status"`
+	StatusDetails *allureStatus      `json:"statusDetails,omitempty"`
+	Start         int64              `json:"start"`
+	Stop          int64              `json:"stop"`
+	Labels        []allureLabel      `json:"labels"`
+	Steps         []allureStep       `json:"steps,omitempty"`
+	Attachments   []allureAttachment `json:"attachments,omitempty"`
+}
+
+type allureStatus struct {
+	Message string `json:"message"`
+	Trace   string `json:"trace,omitempty"`
+}
+
+type allureLabel struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+type allureStep struct {
+	Name   string `json:"name"`
+	Status string `json:"status"`
+	Start  int64  `json:"start"`
+	Stop   int64  `json:"stop"`
+}
+
+type allureAttachment struct {
+	Name   string `json:"name"`
+	Source string `json:"source"`
+	Type   string `json:"type"`
+}
+
+// WriteAllureResults renders the Ginkgo report into an Allure results
+// directory. Container texts become parentSuite/suite labels, Ginkgo
+// labels become Allure tags, and artifact files referenced by report
+// entries are copied in as attachments.
+func WriteAllureResults(report types.Report, resultsDir string) error {
+	if err := os.MkdirAll(resultsDir, 0o755); err != nil {
+		return fmt.Errorf("failed to create allure results directory: %w", err)
+	}
+	for _, spec := range report.SpecReports {
+		if spec.LeafNodeType != types.NodeTypeIt {
+			continue
+		}
+		result := allureResult{
+			UUID:     allureUUID(spec),
+			Name:     spec.LeafNodeText,
+			FullName: spec.FullText(),
+			Status:   allureState(spec.State),
+			Start:    spec.StartTime.UnixMilli(),
+			Stop:     spec.EndTime.UnixMilli(),
+			Labels:   allureLabels(spec),
+		}
+		if result.Status == "failed" || result.Status == "broken" {
+			result.StatusDetails = &allureStatus{
+				Message: spec.Failure.Message,
+				Trace:   spec.Failure.Location.FullStackTrace,
+			}
+		}
+		for _, node := range spec.SpecEvents {
+			if node.SpecEventType != types.SpecEventByStart {
+				continue
+			}
+			result.Steps = append(result.Steps, allureStep{
+				Name:   node.Message,
+				Status: "passed",
+				Start:  node.TimelineLocation.Time.UnixMilli(),
+				Stop:   node.TimelineLocation.Time.UnixMilli(),
+			})
+		}
+		attachments, err := copyAllureAttachments(spec, resultsDir)
+		if err != nil {
+			return err
+		}
+		result.Attachments = attachments
+
+		encoded, err := json.Marshal(result)
+		if err != nil {
+			return fmt.Errorf("failed to encode allure result: %w", err)
+		}
+		path := filepath.Join(resultsDir, result.UUID+"-result.json")
+		if err := os.WriteFile(path, encoded, 0o644); err != nil {
+			return fmt.Errorf("failed to write allure result: %w", err)
+		}
+	}
+	return nil
+}
+
+// allureUUID derives a stable identifier from the spec text so re-running
+// a report overwrites rather than duplicates.
+func allureUUID(spec types.SpecReport) string {
+	sum := sha256.Sum256([]byte(spec.FullText()))
+	return fmt.Sprintf("%x", sum[:16])
+}
+
+func allureState(state types.SpecState) string {
+	switch state {
+	case types.SpecStatePassed:
+		return "passed"
+	case types.SpecStateSkipped, types.SpecStatePending:
+		return "skipped"
+	case types.SpecStateFailed, types.SpecStateTimedout:
+		return "failed"
+	default:
+		return "broken"
+	}
+}
+
+func allureLabels(spec types.SpecReport) []allureLabel {
+	var labels []allureLabel
+	if len(spec.ContainerHierarchyTexts) > 0 {
+		labels = append(labels, allureLabel{Name: "parentSuite", Value: spec.ContainerHierarchyTexts[0]})
+	}
+	if len(spec.ContainerHierarchyTexts) > 1 {
+		labels = append(labels, allureLabel{
+			Name:  "suite",
+			Value: strings.Join(spec.ContainerHierarchyTexts[1:], " › "),
+		})
+	}
+	for _, label := range spec.Labels() {
+		labels = append(labels, allureLabel{Name: "tag", Value: label})
+	}
+	return labels
+}
+
+// copyAllureAttachments copies artifact files referenced by report
+// entries into the results directory under Allure's attachment naming.
+func copyAllureAttachments(spec types.SpecReport, resultsDir string) ([]allureAttachment, error) {
+	var attachments []allureAttachment
+	for _, artifact := range specArtifacts(spec) {
+		if strings.HasPrefix(artifact, "http://") || strings.HasPrefix(artifact, "https://") {
+			continue
+		}
+		content, err := os.ReadFile(artifact)
+		if err != nil {
+			// Missing artifacts are not worth failing the report over.
+			continue
+		}
+		source := fmt.Sprintf("%s-%s-attachment%s",
+			allureUUID(spec), sanitizeRunID(filepath.Base(artifact)), filepath.Ext(artifact))
+		if err := os.WriteFile(filepath.Join(resultsDir, source), content, 0o644); err != nil {
+			return nil, fmt.Errorf("failed to copy allure attachment: %w", err)
+		}
+		attachments = append(attachments, allureAttachment{
+			Name:   filepath.Base(artifact),
+			Source: source,
+			Type:   "text/plain",
+		})
+	}
+	return attachments, nil
+}
@@ -0,0 +1,98 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package reporting
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/onsi/ginkgo/v2/types"
+)
+
+func TestAllureState(t *testing.T) {
+	cases := map[types.SpecState]string{
+		types.SpecStatePassed:      "passed",
+		types.SpecStateSkipped:     "skipped",
+		types.SpecStatePending:     "skipped",
+		types.SpecStateFailed:      "failed",
+		types.SpecStateTimedout:    "failed",
+		types.SpecStatePanicked:    "broken",
+		types.SpecStateInterrupted: "broken",
+	}
+	for state, want := range cases {
+		if got := allureState(state); got != want {
+			t.Errorf("allureState(%s) = %q, want %q", state, got, want)
+		}
+	}
+}
+
+func TestAllureUUIDStable(t *testing.T) {
+	spec := types.SpecReport{ContainerHierarchyTexts: []string{"Import"}, LeafNodeText: "imports"}
+	other := types.SpecReport{ContainerHierarchyTexts: []string{"Import"}, LeafNodeText: "detaches"}
+	if allureUUID(spec) != allureUUID(spec) {
+		t.Error("allureUUID is not deterministic")
+	}
+	if allureUUID(spec) == allureUUID(other) {
+		t.Error("different specs share a UUID")
+	}
+}
+
+func TestWriteAllureResults(t *testing.T) {
+	start := time.Date(2026, 8, 30, 12, 0, 0, 0, time.UTC)
+	report := types.Report{SpecReports: types.SpecReports{
+		{
+			LeafNodeType:            types.NodeTypeIt,
+			State:                   types.SpecStateFailed,
+			ContainerHierarchyTexts: []string{"Provision", "AWS"},
+			LeafNodeText:            "provisions a spot pool",
+			LeafNodeLabels:          []string{"RHACM4K-7473"},
+			StartTime:               start,
+			EndTime:                 start.Add(time.Minute),
+			Failure:                 types.Failure{Message: "pool never converged"},
+		},
+		{
+			// Container nodes produce no result files.
+			LeafNodeType: types.NodeTypeBeforeSuite,
+			State:        types.SpecStatePassed,
+		},
+	}}
+
+	resultsDir := filepath.Join(t.TempDir(), "allure-results")
+	if err := WriteAllureResults(report, resultsDir); err != nil {
+		t.Fatalf("WriteAllureResults: %v", err)
+	}
+
+	entries, err := os.ReadDir(resultsDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 {
+		t.Fatalf("results dir has %d files, want 1", len(entries))
+	}
+
+	content, err := os.ReadFile(filepath.Join(resultsDir, entries[0].Name()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	result := allureResult{}
+	if err := json.Unmarshal(content, &result); err != nil {
+		t.Fatalf("result file is not valid JSON: %v", err)
+	}
+	if result.Status != "failed" || result.StatusDetails == nil ||
+		result.StatusDetails.Message != "pool never converged" {
+		t.Errorf("result = %+v", result)
+	}
+	labels := map[string]string{}
+	for _, label := range result.Labels {
+		labels[label.Name] = label.Value
+	}
+	if labels["parentSuite"] != "Provision" || labels["suite"] != "AWS" || labels["tag"] != "RHACM4K-7473" {
+		t.Errorf("labels = %v", labels)
+	}
+	if result.Stop-result.Start != time.Minute.Milliseconds() {
+		t.Errorf("timing = %d..%d", result.Start, result.Stop)
+	}
+}
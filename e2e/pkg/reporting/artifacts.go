@@ -0,0 +1,117 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package reporting
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/onsi/ginkgo/v2"
+)
+
+// artifactDirEnv is the CI-provided artifact root, the same variable the
+// must-gather collector honors.
+const artifactDirEnv = "ARTIFACT_DIR"
+
+// Artifacts allocates one directory per spec for logs, yaml dumps and
+// must-gather output, and records every file saved there as a report
+// entry so the HTML report and Allure writer link it.
+type Artifacts struct {
+	root string
+
+	mu   sync.Mutex
+	dirs map[string]string
+}
+
+// NewArtifacts roots the manager at dir, falling back to ARTIFACT_DIR and
+// then the working directory.
+func NewArtifacts(dir string) *Artifacts {
+	if dir == "" {
+		dir = os.Getenv(artifactDirEnv)
+	}
+	if dir == "" {
+		dir = "."
+	}
+	return &Artifacts{root: dir, dirs: map[string]string{}}
+}
+
+// SpecDir returns the current spec's artifact directory, creating it on
+// first use. Call it from inside a running spec.
+func (a *Artifacts) SpecDir() (string, error) {
+	spec := ginkgo.CurrentSpecReport()
+	name := sanitizeArtifactName(spec.FullText())
+	if name == "" {
+		name = "suite"
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if dir, ok := a.dirs[name]; ok {
+		return dir, nil
+	}
+	dir := filepath.Join(a.root, name)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", fmt.Errorf("failed to create artifact directory %s: %w", dir, err)
+	}
+	a.dirs[name] = dir
+	return dir, nil
+}
+
+// Save writes content as an artifact of the current spec and records its
+// path in the spec report.
+func (a *Artifacts) Save(name string, content []byte) (string, error) {
+	dir, err := a.SpecDir()
+	if err != nil {
+		return "", err
+	}
+	path := filepath.Join(dir, sanitizeArtifactName(name))
+	if err := os.WriteFile(path, content, 0o644); err != nil {
+		return "", fmt.Errorf("failed to write artifact %s: %w", path, err)
+	}
+	ginkgo.AddReportEntry("artifact", path)
+	return path, nil
+}
+
+// Record registers an externally produced file (e.g. a must-gather
+// directory) as an artifact of the current spec.
+func (a *Artifacts) Record(path string) {
+	ginkgo.AddReportEntry("artifact", path)
+}
+
+// AttachArtifactPaths adds each spec's artifact paths to the matching JUnit
+// test case as system-out lines, for consumers that only read JUnit.
+func AttachArtifactPaths(suites *JUnitTestSuites, specArtifactPaths map[string][]string) {
+	for i := range suites.Suites {
+		for j := range suites.Suites[i].Cases {
+			testcase := &suites.Suites[i].Cases[j]
+			paths := specArtifactPaths[testcase.Name]
+			if len(paths) == 0 {
+				continue
+			}
+			block := "artifacts:\n" + strings.Join(paths, "\n")
+			if testcase.SystemOut == "" {
+				testcase.SystemOut = block
+			} else {
+				testcase.SystemOut += "\n" + block
+			}
+		}
+	}
+}
+
+// sanitizeArtifactName keeps artifact names filesystem-safe across the
+// platforms CI runs on.
+func sanitizeArtifactName(name string) string {
+	replaced := strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9',
+			r == '-', r == '_', r == '.':
+			return r
+		default:
+			return '_'
+		}
+	}, name)
+	return strings.Trim(replaced, "_")
+}
@@ -0,0 +1,180 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package reporting
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/onsi/ginkgo/v2/types"
+)
+
+// knownProviders are the provider labels the coverage matrix groups by,
+// in the order CLC QE lists them.
+var knownProviders = []string{
+	"aws", "gcp", "azure", "vsphere", "openstack", "baremetal",
+	"eks", "gke", "aks", "iks", "roks", "rosa", "hypershift",
+}
+
+// knownOperations are the operation labels forming the matrix columns.
+var knownOperations = []string{
+	"create", "import", "detach", "destroy", "upgrade",
+	"machinepool", "addon", "autoscale",
+}
+
+// CoverageCell aggregates the outcomes of one provider/operation pair.
+type CoverageCell struct {
+	Passed  int
+	Failed  int
+	Skipped int
+}
+
+func (c CoverageCell) String() string {
+	if c.Passed+c.Failed+c.Skipped == 0 {
+		return "-"
+	}
+	if c.Failed > 0 {
+		return fmt.Sprintf("FAIL %d/%d", c.Failed, c.Passed+c.Failed)
+	}
+	if c.Passed == 0 {
+		return fmt.Sprintf("skip %d", c.Skipped)
+	}
+	return fmt.Sprintf("pass %d", c.Passed)
+}
+
+// CoverageMatrix is the provider × operation breakdown of one run.
+type CoverageMatrix struct {
+	cells map[string]map[string]*CoverageCell
+}
+
+// BuildCoverageMatrix derives the matrix from spec labels. A spec counts
+// toward every provider/operation pair its labels name; specs without a
+// provider label are left out — they are hub-side and have no cell.
+func BuildCoverageMatrix(report types.Report) *CoverageMatrix {
+	matrix := &CoverageMatrix{cells: map[string]map[string]*CoverageCell{}}
+	for _, spec := range report.SpecReports {
+		if spec.LeafNodeType != types.NodeTypeIt {
+			continue
+		}
+		providers, operations := classifyLabels(spec.Labels())
+		for _, provider := range providers {
+			for _, operation := range operations {
+				cell := matrix.cell(provider, operation)
+				switch spec.State {
+				case types.SpecStatePassed:
+					cell.Passed++
+				case types.SpecStateSkipped, types.SpecStatePending:
+					cell.Skipped++
+				default:
+					cell.Failed++
+				}
+			}
+		}
+	}
+	return matrix
+}
+
+func classifyLabels(labels []string) (providers, operations []string) {
+	for _, label := range labels {
+		lowered := strings.ToLower(label)
+		for _, provider := range knownProviders {
+			if lowered == provider {
+				providers = append(providers, provider)
+			}
+		}
+		for _, operation := range knownOperations {
+			if lowered == operation {
+				operations = append(operations, operation)
+			}
+		}
+	}
+	return providers, operations
+}
+
+func (m *CoverageMatrix) cell(provider, operation string) *CoverageCell {
+	if m.cells[provider] == nil {
+		m.cells[provider] = map[string]*CoverageCell{}
+	}
+	if m.cells[provider][operation] == nil {
+		m.cells[provider][operation] = &CoverageCell{}
+	}
+	return m.cells[provider][operation]
+}
+
+// Cell returns the aggregated outcomes for one pair; the zero cell when
+// the run exercised none.
+func (m *CoverageMatrix) Cell(provider, operation string) CoverageCell {
+	if m.cells[provider] == nil || m.cells[provider][operation] == nil {
+		return CoverageCell{}
+	}
+	return *m.cells[provider][operation]
+}
+
+// String renders the matrix as an aligned text table with providers as
+// rows, only including providers and operations the run touched.
+func (m *CoverageMatrix) String() string {
+	providers := m.activeProviders()
+	operations := m.activeOperations()
+	if len(providers) == 0 {
+		return "no provider-labeled specs in this run"
+	}
+
+	widths := make([]int, len(operations)+1)
+	widths[0] = len("provider")
+	for _, provider := range providers {
+		if len(provider) > widths[0] {
+			widths[0] = len(provider)
+		}
+	}
+	for i, operation := range operations {
+		widths[i+1] = len(operation)
+		for _, provider := range providers {
+			if cell := m.Cell(provider, operation).String(); len(cell) > widths[i+1] {
+				widths[i+1] = len(cell)
+			}
+		}
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "%-*s", widths[0], "provider")
+	for i, operation := range operations {
+		fmt.Fprintf(&b, "  %-*s", widths[i+1], operation)
+	}
+	b.WriteString("\n")
+	for _, provider := range providers {
+		fmt.Fprintf(&b, "%-*s", widths[0], provider)
+		for i, operation := range operations {
+			fmt.Fprintf(&b, "  %-*s", widths[i+1], m.Cell(provider, operation).String())
+		}
+		b.WriteString("\n")
+	}
+	return strings.TrimRight(b.String(), "\n")
+}
+
+func (m *CoverageMatrix) activeProviders() []string {
+	var active []string
+	for _, provider := range knownProviders {
+		if len(m.cells[provider]) > 0 {
+			active = append(active, provider)
+		}
+	}
+	sort.Strings(active)
+	return active
+}
+
+func (m *CoverageMatrix) activeOperations() []string {
+	seen := map[string]bool{}
+	for _, operations := range m.cells {
+		for operation := range operations {
+			seen[operation] = true
+		}
+	}
+	var active []string
+	for _, operation := range knownOperations {
+		if seen[operation] {
+			active = append(active, operation)
+		}
+	}
+	return active
+}
@@ -0,0 +1,150 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package reporting
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/onsi/ginkgo/v2/types"
+)
+
+// DBSink persists spec results into a relational database for trend
+// queries across runs. The caller opens the *sql.DB (sqlite for local
+// runs, postgres in CI) and passes the driver name so placeholders can be
+// rendered for either dialect.
+type DBSink struct {
+	db     *sql.DB
+	driver string
+}
+
+// NewDBSink wraps an open database handle. driver is the name passed to
+// sql.Open, e.g. "sqlite3" or "postgres".
+func NewDBSink(db *sql.DB, driver string) *DBSink {
+	return &DBSink{db: db, driver: driver}
+}
+
+// resultsSchema is intentionally lowest-common-denominator SQL so the
+// same statements run on SQLite and Postgres.
+const resultsSchema = `
+CREATE TABLE IF NOT EXISTS runs (
+	run_id TEXT PRIMARY KEY,
+	suite TEXT NOT NULL,
+	started_at TIMESTAMP NOT NULL,
+	duration_seconds DOUBLE PRECISION NOT NULL,
+	succeeded BOOLEAN NOT NULL,
+	hub TEXT,
+	snapshot TEXT
+);
+CREATE TABLE IF NOT EXISTS spec_results (
+	run_id TEXT NOT NULL,
+	case_id TEXT,
+	name TEXT NOT NULL,
+	labels TEXT,
+	state TEXT NOT NULL,
+	duration_seconds DOUBLE PRECISION NOT NULL,
+	failure TEXT
+);
+CREATE INDEX IF NOT EXISTS spec_results_case ON spec_results (case_id);
+CREATE INDEX IF NOT EXISTS spec_results_run ON spec_results (run_id);
+`
+
+// Init creates the schema if it does not exist yet.
+func (s *DBSink) Init(ctx context.Context) error {
+	for _, statement := range strings.Split(resultsSchema, ";") {
+		statement = strings.TrimSpace(statement)
+		if statement == "" {
+			continue
+		}
+		if _, err := s.db.ExecContext(ctx, statement); err != nil {
+			return fmt.Errorf("failed to create results schema: %w", err)
+		}
+	}
+	return nil
+}
+
+// RunMetadata identifies a run beyond what the Ginkgo report carries.
+type RunMetadata struct {
+	// RunID is the unique run identifier, typically the CI build ID.
+	RunID string
+	// Hub names the hub the run executed against.
+	Hub string
+	// Snapshot is the product snapshot or version under test.
+	Snapshot string
+}
+
+// Store writes the run and every leaf spec result. It is idempotent per
+// run ID: re-storing a run first deletes its previous rows, which covers
+// CI retries.
+func (s *DBSink) Store(ctx context.Context, meta RunMetadata, report types.Report) error {
+	if meta.RunID == "" {
+		return fmt.Errorf("run ID is required")
+	}
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin results transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	for _, statement := range []string{
+		"DELETE FROM spec_results WHERE run_id = " + s.placeholder(1),
+		"DELETE FROM runs WHERE run_id = " + s.placeholder(1),
+	} {
+		if _, err := tx.ExecContext(ctx, statement, meta.RunID); err != nil {
+			return fmt.Errorf("failed to clear previous run rows: %w", err)
+		}
+	}
+
+	insertRun := fmt.Sprintf(
+		"INSERT INTO runs (run_id, suite, started_at, duration_seconds, succeeded, hub, snapshot) VALUES (%s)",
+		s.placeholders(7))
+	_, err = tx.ExecContext(ctx, insertRun,
+		meta.RunID, report.SuiteDescription, report.StartTime.UTC(),
+		report.RunTime.Seconds(), report.SuiteSucceeded, meta.Hub, meta.Snapshot)
+	if err != nil {
+		return fmt.Errorf("failed to insert run row: %w", err)
+	}
+
+	insertSpec := fmt.Sprintf(
+		"INSERT INTO spec_results (run_id, case_id, name, labels, state, duration_seconds, failure) VALUES (%s)",
+		s.placeholders(7))
+	for _, spec := range report.SpecReports {
+		if spec.LeafNodeType != types.NodeTypeIt {
+			continue
+		}
+		failure := ""
+		if spec.State != types.SpecStatePassed && spec.State != types.SpecStateSkipped {
+			failure = formatSpecFailure(spec)
+		}
+		_, err = tx.ExecContext(ctx, insertSpec,
+			meta.RunID, polarionCaseID(spec), spec.FullText(),
+			strings.Join(spec.Labels(), ","), spec.State.String(),
+			spec.RunTime.Seconds(), failure)
+		if err != nil {
+			return fmt.Errorf("failed to insert spec result: %w", err)
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit results: %w", err)
+	}
+	return nil
+}
+
+// placeholder renders the n-th statement parameter for the sink's
+// dialect: $n for postgres, ? otherwise.
+func (s *DBSink) placeholder(n int) string {
+	if strings.Contains(s.driver, "postgres") || s.driver == "pgx" {
+		return fmt.Sprintf("$%d", n)
+	}
+	return "?"
+}
+
+func (s *DBSink) placeholders(count int) string {
+	parts := make([]string, count)
+	for i := range parts {
+		parts[i] = s.placeholder(i + 1)
+	}
+	return strings.Join(parts, ", ")
+}
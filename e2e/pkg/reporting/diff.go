@@ -0,0 +1,114 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package reporting
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// slowdownFactor marks a spec as significantly slower when its duration
+// grew by at least this factor over a meaningful baseline.
+const (
+	slowdownFactor      = 1.5
+	slowdownMinBaseline = 10.0 // seconds; noise below this is not worth flagging
+)
+
+// ReportDiff summarizes what changed between two runs.
+type ReportDiff struct {
+	NewlyFailing []string
+	NewlyPassing []string
+	NewlySkipped []string
+	// Slower lists specs whose duration regressed, formatted with both
+	// durations.
+	Slower []string
+}
+
+// Empty reports whether the runs are equivalent for triage purposes.
+func (d *ReportDiff) Empty() bool {
+	return len(d.NewlyFailing) == 0 && len(d.NewlyPassing) == 0 &&
+		len(d.NewlySkipped) == 0 && len(d.Slower) == 0
+}
+
+// String renders the diff in the section form nightly triage reads.
+func (d *ReportDiff) String() string {
+	if d.Empty() {
+		return "no differences between runs"
+	}
+	var b strings.Builder
+	writeDiffSection(&b, "newly failing", d.NewlyFailing)
+	writeDiffSection(&b, "newly passing", d.NewlyPassing)
+	writeDiffSection(&b, "newly skipped", d.NewlySkipped)
+	writeDiffSection(&b, "significantly slower", d.Slower)
+	return strings.TrimRight(b.String(), "\n")
+}
+
+func writeDiffSection(b *strings.Builder, title string, entries []string) {
+	if len(entries) == 0 {
+		return
+	}
+	fmt.Fprintf(b, "%s (%d):\n", title, len(entries))
+	for _, entry := range entries {
+		fmt.Fprintf(b, "  %s\n", entry)
+	}
+}
+
+type specOutcome struct {
+	state    string // "passed", "failed", "skipped"
+	duration float64
+}
+
+// DiffJUnitReports compares a baseline run against the current one.
+// Specs present in only one run are ignored — suite composition changes
+// are not regressions.
+func DiffJUnitReports(baseline, current *JUnitTestSuites) *ReportDiff {
+	baselineOutcomes := collectOutcomes(baseline)
+	currentOutcomes := collectOutcomes(current)
+
+	diff := &ReportDiff{}
+	names := make([]string, 0, len(currentOutcomes))
+	for name := range currentOutcomes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		now := currentOutcomes[name]
+		before, ok := baselineOutcomes[name]
+		if !ok {
+			continue
+		}
+		switch {
+		case now.state == "failed" && before.state != "failed":
+			diff.NewlyFailing = append(diff.NewlyFailing, name)
+		case now.state == "passed" && before.state == "failed":
+			diff.NewlyPassing = append(diff.NewlyPassing, name)
+		case now.state == "skipped" && before.state != "skipped":
+			diff.NewlySkipped = append(diff.NewlySkipped, name)
+		}
+		if now.state == "passed" && before.state == "passed" &&
+			before.duration >= slowdownMinBaseline && now.duration >= before.duration*slowdownFactor {
+			diff.Slower = append(diff.Slower,
+				fmt.Sprintf("%s (%.0fs -> %.0fs)", name, before.duration, now.duration))
+		}
+	}
+	return diff
+}
+
+func collectOutcomes(suites *JUnitTestSuites) map[string]specOutcome {
+	outcomes := map[string]specOutcome{}
+	for _, suite := range suites.Suites {
+		for _, testcase := range suite.Cases {
+			outcome := specOutcome{state: "passed", duration: testcase.Time}
+			switch {
+			case testcase.Skipped != nil:
+				outcome.state = "skipped"
+			case testcase.Failure != nil || testcase.Error != nil:
+				outcome.state = "failed"
+			}
+			outcomes[testcase.Name] = outcome
+		}
+	}
+	return outcomes
+}
@@ -0,0 +1,76 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package reporting
+
+import (
+	"strings"
+	"testing"
+)
+
+func diffSuite(cases ...JUnitTestCase) *JUnitTestSuites {
+	return &JUnitTestSuites{Suites: []JUnitTestSuite{{Name: "e2e", Cases: cases}}}
+}
+
+func TestDiffJUnitReports(t *testing.T) {
+	baseline := diffSuite(
+		JUnitTestCase{Name: "provisions", Time: 20},
+		JUnitTestCase{Name: "imports", Failure: &JUnitMessage{Message: "boom"}},
+		JUnitTestCase{Name: "detaches", Time: 5},
+		JUnitTestCase{Name: "upgrades", Time: 30},
+		JUnitTestCase{Name: "removed spec", Time: 1},
+	)
+	current := diffSuite(
+		// Slower by more than the factor over a meaningful baseline.
+		JUnitTestCase{Name: "provisions", Time: 35},
+		// Was failing, passes now.
+		JUnitTestCase{Name: "imports", Time: 10},
+		// Below the minimum baseline: slowdown is noise, not a finding.
+		JUnitTestCase{Name: "detaches", Time: 9},
+		// Newly skipped.
+		JUnitTestCase{Name: "upgrades", Skipped: &JUnitMessage{Message: "quarantined"}},
+		// Only in the current run: ignored.
+		JUnitTestCase{Name: "brand new spec", Failure: &JUnitMessage{Message: "boom"}},
+	)
+
+	diff := DiffJUnitReports(baseline, current)
+	if len(diff.NewlyFailing) != 0 {
+		t.Errorf("NewlyFailing = %v, want none (new specs are not regressions)", diff.NewlyFailing)
+	}
+	if len(diff.NewlyPassing) != 1 || diff.NewlyPassing[0] != "imports" {
+		t.Errorf("NewlyPassing = %v", diff.NewlyPassing)
+	}
+	if len(diff.NewlySkipped) != 1 || diff.NewlySkipped[0] != "upgrades" {
+		t.Errorf("NewlySkipped = %v", diff.NewlySkipped)
+	}
+	if len(diff.Slower) != 1 || !strings.Contains(diff.Slower[0], "provisions") {
+		t.Errorf("Slower = %v, want only provisions", diff.Slower)
+	}
+
+	rendered := diff.String()
+	for _, section := range []string{"newly passing", "newly skipped", "significantly slower"} {
+		if !strings.Contains(rendered, section) {
+			t.Errorf("String() missing section %q:\n%s", section, rendered)
+		}
+	}
+}
+
+func TestDiffJUnitReportsNewFailure(t *testing.T) {
+	baseline := diffSuite(JUnitTestCase{Name: "imports", Time: 5})
+	current := diffSuite(JUnitTestCase{Name: "imports", Error: &JUnitMessage{Message: "panic"}})
+
+	diff := DiffJUnitReports(baseline, current)
+	if len(diff.NewlyFailing) != 1 || diff.NewlyFailing[0] != "imports" {
+		t.Errorf("NewlyFailing = %v", diff.NewlyFailing)
+	}
+}
+
+func TestDiffJUnitReportsEmpty(t *testing.T) {
+	suites := diffSuite(JUnitTestCase{Name: "imports", Time: 5})
+	diff := DiffJUnitReports(suites, suites)
+	if !diff.Empty() {
+		t.Errorf("identical runs diff non-empty: %+v", diff)
+	}
+	if diff.String() != "no differences between runs" {
+		t.Errorf("String() = %q", diff.String())
+	}
+}
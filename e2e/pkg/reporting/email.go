@@ -0,0 +1,154 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package reporting
+
+import (
+	"fmt"
+	"net/smtp"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/onsi/ginkgo/v2/types"
+)
+
+// Email digest environment variables.
+const (
+	smtpHostEnv      = "SMTP_HOST"
+	smtpPortEnv      = "SMTP_PORT"
+	smtpUserEnv      = "SMTP_USER"
+	smtpPasswordEnv  = "SMTP_PASSWORD"
+	emailFromEnv     = "EMAIL_FROM"
+	emailToEnv       = "EMAIL_TO" // comma-separated
+	emailDigestLimit = 10         // top failures included in the body
+)
+
+// EmailOptions configures the digest reporter.
+type EmailOptions struct {
+	SMTPHost     string
+	SMTPPort     string
+	Username     string
+	Password     string
+	From         string
+	To           []string
+	ArtifactsURL string
+}
+
+// EmailOptionsFromEnv reads the SMTP configuration from the environment.
+func EmailOptionsFromEnv() EmailOptions {
+	opts := EmailOptions{
+		SMTPHost:     os.Getenv(smtpHostEnv),
+		SMTPPort:     os.Getenv(smtpPortEnv),
+		Username:     os.Getenv(smtpUserEnv),
+		Password:     os.Getenv(smtpPasswordEnv),
+		From:         os.Getenv(emailFromEnv),
+		ArtifactsURL: os.Getenv(artifactsURLEnv),
+	}
+	if recipients := os.Getenv(emailToEnv); recipients != "" {
+		for _, recipient := range strings.Split(recipients, ",") {
+			opts.To = append(opts.To, strings.TrimSpace(recipient))
+		}
+	}
+	if opts.SMTPPort == "" {
+		opts.SMTPPort = "587"
+	}
+	return opts
+}
+
+// SendRunDigest mails the run summary with the top failures and artifact
+// links. It is a no-op when no recipients are configured, so it can be
+// registered unconditionally in ReportAfterSuite.
+func SendRunDigest(opts EmailOptions, report types.Report) error {
+	if len(opts.To) == 0 {
+		return nil
+	}
+	if opts.SMTPHost == "" || opts.From == "" {
+		return fmt.Errorf("email digest needs %s and %s", smtpHostEnv, emailFromEnv)
+	}
+
+	subject, body := formatRunDigest(report, opts.ArtifactsURL)
+	var message strings.Builder
+	fmt.Fprintf(&message, "From: %s\r\n", opts.From)
+	fmt.Fprintf(&message, "To: %s\r\n", strings.Join(opts.To, ", "))
+	fmt.Fprintf(&message, "Subject: %s\r\n", subject)
+	message.WriteString("MIME-Version: 1.0\r\nContent-Type: text/plain; charset=utf-8\r\n\r\n")
+	message.WriteString(body)
+
+	address := opts.SMTPHost + ":" + opts.SMTPPort
+	var auth smtp.Auth
+	if opts.Username != "" {
+		auth = smtp.PlainAuth("", opts.Username, opts.Password, opts.SMTPHost)
+	}
+	if err := smtp.SendMail(address, auth, opts.From, opts.To, []byte(message.String())); err != nil {
+		return fmt.Errorf("failed to send email digest: %w", err)
+	}
+	return nil
+}
+
+func formatRunDigest(report types.Report, artifactsURL string) (subject, body string) {
+	var passed, failed, skipped int
+	type failedSpec struct {
+		name     string
+		duration time.Duration
+		message  string
+	}
+	var failures []failedSpec
+	for _, spec := range report.SpecReports {
+		if spec.LeafNodeType != types.NodeTypeIt {
+			continue
+		}
+		switch spec.State {
+		case types.SpecStatePassed:
+			passed++
+		case types.SpecStateSkipped, types.SpecStatePending:
+			skipped++
+		default:
+			failed++
+			failures = append(failures, failedSpec{
+				name:     spec.FullText(),
+				duration: spec.RunTime,
+				message:  firstLine(spec.Failure.Message),
+			})
+		}
+	}
+
+	verdict := "PASSED"
+	if !report.SuiteSucceeded {
+		verdict = "FAILED"
+	}
+	subject = fmt.Sprintf("[e2e] %s %s: %d passed, %d failed, %d skipped",
+		report.SuiteDescription, verdict, passed, failed, skipped)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "Suite %s %s in %s (started %s).\n\n",
+		report.SuiteDescription, verdict, report.RunTime.Round(time.Second),
+		report.StartTime.Format(time.RFC1123))
+	fmt.Fprintf(&b, "Passed:  %d\nFailed:  %d\nSkipped: %d\n", passed, failed, skipped)
+
+	if len(failures) > 0 {
+		// Longest-running failures first: they are usually the timeouts
+		// worth reading about.
+		sort.Slice(failures, func(i, j int) bool { return failures[i].duration > failures[j].duration })
+		limit := len(failures)
+		if limit > emailDigestLimit {
+			limit = emailDigestLimit
+		}
+		fmt.Fprintf(&b, "\nTop failures (%d of %d):\n", limit, len(failures))
+		for _, failure := range failures[:limit] {
+			fmt.Fprintf(&b, "  - %s (%s)\n      %s\n",
+				failure.name, failure.duration.Round(time.Second), failure.message)
+		}
+	}
+	if artifactsURL != "" {
+		fmt.Fprintf(&b, "\nArtifacts: %s\n", artifactsURL)
+	}
+	return subject, b.String()
+}
+
+func firstLine(text string) string {
+	if index := strings.IndexByte(text, '\n'); index >= 0 {
+		return text[:index]
+	}
+	return text
+}
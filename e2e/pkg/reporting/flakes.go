@@ -0,0 +1,133 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package reporting
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// flakeCaseIDPattern extracts the RHACM4K ID embedded anywhere in a JUnit
+// test case name, the form the Polarion exporter and the Ginkgo labels
+// both produce.
+var flakeCaseIDPattern = regexp.MustCompile(`RHACM4K-\d+`)
+
+// FlakeStats aggregates one test case's history.
+type FlakeStats struct {
+	CaseID string
+	Runs   int
+	Fails  int
+}
+
+// FlakeRate is the fraction of historical runs that failed.
+func (s FlakeStats) FlakeRate() float64 {
+	if s.Runs == 0 {
+		return 0
+	}
+	return float64(s.Fails) / float64(s.Runs)
+}
+
+// FlakeHistory holds per-case statistics built from past JUnit reports.
+type FlakeHistory struct {
+	stats map[string]*FlakeStats
+}
+
+// LoadFlakeHistory ingests historical JUnit reports. Reports that fail to
+// parse are skipped — a corrupt old artifact must not fail the current
+// run.
+func LoadFlakeHistory(paths []string) *FlakeHistory {
+	history := &FlakeHistory{stats: map[string]*FlakeStats{}}
+	for _, path := range paths {
+		parsed, err := ReadJUnitFile(path)
+		if err != nil {
+			continue
+		}
+		for _, suite := range parsed.Suites {
+			for _, testcase := range suite.Cases {
+				caseID := flakeCaseIDPattern.FindString(testcase.Classname + " " + testcase.Name)
+				if caseID == "" {
+					continue
+				}
+				if history.stats[caseID] == nil {
+					history.stats[caseID] = &FlakeStats{CaseID: caseID}
+				}
+				stats := history.stats[caseID]
+				if testcase.Skipped != nil {
+					continue
+				}
+				stats.Runs++
+				if testcase.Failure != nil || testcase.Error != nil {
+					stats.Fails++
+				}
+			}
+		}
+	}
+	return history
+}
+
+// Stats returns the history of one case, or nil when it has none.
+func (h *FlakeHistory) Stats(caseID string) *FlakeStats {
+	return h.stats[caseID]
+}
+
+// AnnotateReport stamps each failed test case of the current report with
+// its historical flake rate, so a reviewer can tell a known-flaky failure
+// from a fresh regression without leaving the report.
+func (h *FlakeHistory) AnnotateReport(suites *JUnitTestSuites) {
+	for i := range suites.Suites {
+		for j := range suites.Suites[i].Cases {
+			testcase := &suites.Suites[i].Cases[j]
+			if testcase.Failure == nil && testcase.Error == nil {
+				continue
+			}
+			caseID := flakeCaseIDPattern.FindString(testcase.Classname + " " + testcase.Name)
+			stats := h.Stats(caseID)
+			if stats == nil || stats.Runs == 0 {
+				continue
+			}
+			annotation := fmt.Sprintf("\n[flake history: failed %d of %d past runs (%.0f%%)]",
+				stats.Fails, stats.Runs, stats.FlakeRate()*100)
+			if testcase.Failure != nil {
+				testcase.Failure.Body += annotation
+			} else {
+				testcase.Error.Body += annotation
+			}
+		}
+	}
+}
+
+// QuarantineCandidates returns the case IDs whose flake rate meets the
+// threshold over at least minRuns runs, sorted worst first — the input
+// for a quarantine file.
+func (h *FlakeHistory) QuarantineCandidates(threshold float64, minRuns int) []FlakeStats {
+	var candidates []FlakeStats
+	for _, stats := range h.stats {
+		if stats.Runs >= minRuns && stats.FlakeRate() >= threshold {
+			candidates = append(candidates, *stats)
+		}
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		if candidates[i].FlakeRate() != candidates[j].FlakeRate() {
+			return candidates[i].FlakeRate() > candidates[j].FlakeRate()
+		}
+		return candidates[i].CaseID < candidates[j].CaseID
+	})
+	return candidates
+}
+
+// WriteQuarantineFile writes the candidates one case ID per line with a
+// trailing rate comment, a format both humans and label filters can
+// consume.
+func WriteQuarantineFile(candidates []FlakeStats, path string) error {
+	var b strings.Builder
+	for _, stats := range candidates {
+		fmt.Fprintf(&b, "%s # %.0f%% over %d runs\n", stats.CaseID, stats.FlakeRate()*100, stats.Runs)
+	}
+	if err := os.WriteFile(path, []byte(b.String()), 0o644); err != nil {
+		return fmt.Errorf("failed to write quarantine file: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,122 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package reporting
+
+import (
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// historyRun writes one historical JUnit report where RHACM4K-1 always
+// passes and RHACM4K-2 fails when told to.
+func historyRun(t *testing.T, dir, name string, secondFails bool) string {
+	t.Helper()
+	var failure *JUnitMessage
+	if secondFails {
+		failure = &JUnitMessage{Message: "timed out"}
+	}
+	path := filepath.Join(dir, name)
+	err := WriteJUnitFile(&JUnitTestSuites{Suites: []JUnitTestSuite{{
+		Name: "e2e",
+		Cases: []JUnitTestCase{
+			{Name: "RHACM4K-1 provisions", Classname: "create"},
+			{Name: "RHACM4K-2 imports", Classname: "import", Failure: failure},
+			{Name: "no case id here", Classname: "misc"},
+		},
+	}}}, path)
+	if err != nil {
+		t.Fatalf("WriteJUnitFile: %v", err)
+	}
+	return path
+}
+
+func TestLoadFlakeHistory(t *testing.T) {
+	dir := t.TempDir()
+	paths := []string{
+		historyRun(t, dir, "run1.xml", true),
+		historyRun(t, dir, "run2.xml", false),
+		historyRun(t, dir, "run3.xml", true),
+	}
+	// A corrupt artifact must be skipped, not fail the analysis.
+	corrupt := filepath.Join(dir, "corrupt.xml")
+	if err := os.WriteFile(corrupt, []byte("<testsuites"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	history := LoadFlakeHistory(append(paths, corrupt))
+
+	stable := history.Stats("RHACM4K-1")
+	if stable == nil || stable.Runs != 3 || stable.Fails != 0 {
+		t.Errorf("RHACM4K-1 stats = %+v, want 3 runs / 0 fails", stable)
+	}
+	flaky := history.Stats("RHACM4K-2")
+	if flaky == nil || flaky.Runs != 3 || flaky.Fails != 2 {
+		t.Fatalf("RHACM4K-2 stats = %+v, want 3 runs / 2 fails", flaky)
+	}
+	if rate := flaky.FlakeRate(); math.Abs(rate-2.0/3.0) > 1e-9 {
+		t.Errorf("FlakeRate = %v, want 2/3", rate)
+	}
+	if history.Stats("RHACM4K-999") != nil {
+		t.Error("unknown case has stats")
+	}
+	if (FlakeStats{}).FlakeRate() != 0 {
+		t.Error("FlakeRate of an empty history is not 0")
+	}
+}
+
+func TestAnnotateReport(t *testing.T) {
+	dir := t.TempDir()
+	history := LoadFlakeHistory([]string{
+		historyRun(t, dir, "run1.xml", true),
+		historyRun(t, dir, "run2.xml", true),
+	})
+
+	current := &JUnitTestSuites{Suites: []JUnitTestSuite{{
+		Cases: []JUnitTestCase{
+			{Name: "RHACM4K-2 imports", Failure: &JUnitMessage{Body: "timed out"}},
+			{Name: "RHACM4K-1 provisions"},
+		},
+	}}}
+	history.AnnotateReport(current)
+
+	annotated := current.Suites[0].Cases[0].Failure.Body
+	if want := "[flake history: failed 2 of 2 past runs (100%)]"; !strings.Contains(annotated, want) {
+		t.Errorf("failure body %q missing annotation %q", annotated, want)
+	}
+	// Passing cases stay untouched.
+	if current.Suites[0].Cases[1].Failure != nil {
+		t.Error("passing case grew a failure")
+	}
+}
+
+func TestQuarantineCandidates(t *testing.T) {
+	dir := t.TempDir()
+	history := LoadFlakeHistory([]string{
+		historyRun(t, dir, "run1.xml", true),
+		historyRun(t, dir, "run2.xml", true),
+		historyRun(t, dir, "run3.xml", false),
+	})
+
+	candidates := history.QuarantineCandidates(0.5, 3)
+	if len(candidates) != 1 || candidates[0].CaseID != "RHACM4K-2" {
+		t.Fatalf("candidates = %+v, want exactly RHACM4K-2", candidates)
+	}
+	// minRuns above the history depth filters everything out.
+	if got := history.QuarantineCandidates(0.5, 4); len(got) != 0 {
+		t.Errorf("minRuns=4 still returned %+v", got)
+	}
+
+	path := filepath.Join(dir, "quarantine.txt")
+	if err := WriteQuarantineFile(candidates, path); err != nil {
+		t.Fatalf("WriteQuarantineFile: %v", err)
+	}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "RHACM4K-2 # 67% over 3 runs\n"; string(content) != want {
+		t.Errorf("quarantine file = %q, want %q", content, want)
+	}
+}
@@ -0,0 +1,100 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package reporting
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// GrafanaOptions configures the generated dashboard.
+type GrafanaOptions struct {
+	// Title of the dashboard. Defaults to "ACM e2e runs".
+	Title string
+	// Datasource is the Prometheus datasource UID scraping the
+	// Pushgateway the suite pushes to.
+	Datasource string
+	// Job is the Pushgateway job name used in the queries; must match
+	// PushgatewayOptions.Job.
+	Job string
+}
+
+// WriteGrafanaDashboard generates a ready-to-import Grafana dashboard
+// wired to the metrics this package pushes: suite verdict, pass/fail
+// counts, per-label durations and applier latencies. The JSON targets
+// the dashboard schema Grafana 9+ imports without edits.
+func WriteGrafanaDashboard(opts GrafanaOptions, path string) error {
+	if opts.Datasource == "" || opts.Job == "" {
+		return fmt.Errorf("grafana dashboard needs a datasource UID and pushgateway job")
+	}
+	title := opts.Title
+	if title == "" {
+		title = "ACM e2e runs"
+	}
+	jobSelector := fmt.Sprintf(`job=%q`, opts.Job)
+
+	dashboard := map[string]interface{}{
+		"title":         title,
+		"schemaVersion": 38,
+		"tags":          []string{"e2e", "acm"},
+		"time":          map[string]string{"from": "now-30d", "to": "now"},
+		"refresh":       "5m",
+		"panels": []map[string]interface{}{
+			statPanel(1, "Last run verdict", opts.Datasource,
+				fmt.Sprintf("e2e_suite_succeeded{%s}", jobSelector), grid(0, 0, 4, 4)),
+			statPanel(2, "Last run duration", opts.Datasource,
+				fmt.Sprintf("e2e_suite_duration_seconds{%s}", jobSelector), grid(4, 0, 4, 4)),
+			timeseriesPanel(3, "Specs by result", opts.Datasource,
+				fmt.Sprintf("e2e_specs_total{%s}", jobSelector), "{{result}}", grid(8, 0, 16, 8)),
+			timeseriesPanel(4, "Pass rate", opts.Datasource,
+				fmt.Sprintf(`e2e_specs_total{%s,result="passed"} / ignoring(result) (e2e_specs_total{%s,result="passed"} + e2e_specs_total{%s,result="failed"})`,
+					jobSelector, jobSelector, jobSelector),
+				"pass rate", grid(0, 4, 8, 8)),
+			timeseriesPanel(5, "Duration per label", opts.Datasource,
+				fmt.Sprintf("e2e_label_duration_seconds{%s}", jobSelector), "{{label}}", grid(0, 12, 12, 8)),
+			timeseriesPanel(6, "Applier max latency", opts.Datasource,
+				fmt.Sprintf("e2e_applier_operation_max_latency_seconds{%s}", jobSelector),
+				"{{verb}} {{kind}}", grid(12, 12, 12, 8)),
+		},
+	}
+
+	encoded, err := json.MarshalIndent(dashboard, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode grafana dashboard: %w", err)
+	}
+	if err := os.WriteFile(path, encoded, 0o644); err != nil {
+		return fmt.Errorf("failed to write grafana dashboard: %w", err)
+	}
+	return nil
+}
+
+func statPanel(id int, title, datasource, expr string, gridPos map[string]int) map[string]interface{} {
+	return map[string]interface{}{
+		"id":         id,
+		"type":       "stat",
+		"title":      title,
+		"datasource": map[string]string{"type": "prometheus", "uid": datasource},
+		"gridPos":    gridPos,
+		"targets": []map[string]interface{}{
+			{"expr": expr, "refId": "A"},
+		},
+	}
+}
+
+func timeseriesPanel(id int, title, datasource, expr, legend string, gridPos map[string]int) map[string]interface{} {
+	return map[string]interface{}{
+		"id":         id,
+		"type":       "timeseries",
+		"title":      title,
+		"datasource": map[string]string{"type": "prometheus", "uid": datasource},
+		"gridPos":    gridPos,
+		"targets": []map[string]interface{}{
+			{"expr": expr, "legendFormat": legend, "refId": "A"},
+		},
+	}
+}
+
+func grid(x, y, w, h int) map[string]int {
+	return map[string]int{"x": x, "y": y, "w": w, "h": h}
+}
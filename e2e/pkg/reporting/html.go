@@ -0,0 +1,168 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package reporting
+
+import (
+	"fmt"
+	"html/template"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/onsi/ginkgo/v2/types"
+)
+
+// htmlReportTemplate renders the whole run into one self-contained page;
+// no external assets, so the file can be attached to CI artifacts and
+// opened anywhere.
+const htmlReportTemplate = `<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>{{.SuiteName}} — e2e report</title>
+<style>
+body { font-family: sans-serif; margin: 2em; }
+h1 { font-size: 1.4em; }
+.summary span { margin-right: 1.5em; }
+.passed { color: #2e7d32; }
+.failed { color: #c62828; }
+.skipped { color: #757575; }
+table { border-collapse: collapse; width: 100%; margin-top: 1em; }
+th, td { text-align: left; padding: 0.4em 0.8em; border-bottom: 1px solid #ddd; vertical-align: top; }
+.container { color: #555; }
+.label { background: #eee; border-radius: 3px; padding: 0.1em 0.4em; margin-right: 0.3em; font-size: 0.85em; }
+pre { background: #f7f7f7; padding: 0.8em; overflow-x: auto; font-size: 0.85em; }
+details { margin-top: 0.3em; }
+</style>
+</head>
+<body>
+<h1>{{.SuiteName}}</h1>
+<p class="summary">
+<span>started {{.StartTime}}</span>
+<span>duration {{.Duration}}</span>
+<span class="passed">{{.Passed}} passed</span>
+<span class="failed">{{.Failed}} failed</span>
+<span class="skipped">{{.Skipped}} skipped</span>
+</p>
+<table>
+<tr><th>Result</th><th>Spec</th><th>Duration</th></tr>
+{{range .Specs}}
+<tr>
+<td class="{{.StateClass}}">{{.State}}</td>
+<td>
+<span class="container">{{.ContainerText}}</span> {{.LeafText}}<br>
+{{range .Labels}}<span class="label">{{.}}</span>{{end}}
+{{if .Failure}}<details><summary>failure</summary><pre>{{.Failure}}</pre></details>{{end}}
+{{if .Artifacts}}<details><summary>artifacts</summary><ul>{{range .Artifacts}}<li><a href="{{.}}">{{.}}</a></li>{{end}}</ul></details>{{end}}
+</td>
+<td>{{.Duration}}</td>
+</tr>
+{{end}}
+</table>
+</body>
+</html>
+`
+
+type htmlReportData struct {
+	SuiteName string
+	StartTime string
+	Duration  string
+	Passed    int
+	Failed    int
+	Skipped   int
+	Specs     []htmlSpecData
+}
+
+type htmlSpecData struct {
+	State         string
+	StateClass    string
+	ContainerText string
+	LeafText      string
+	Labels        []string
+	Duration      string
+	Failure       string
+	Artifacts     []string
+}
+
+// WriteHTMLReport renders the Ginkgo report as a single-file HTML page.
+// Report entries whose value looks like a file path or URL are linked as
+// artifacts of their spec.
+func WriteHTMLReport(report types.Report, path string) error {
+	data := htmlReportData{
+		SuiteName: report.SuiteDescription,
+		StartTime: report.StartTime.Format(time.RFC1123),
+		Duration:  report.RunTime.Round(time.Second).String(),
+	}
+	for _, spec := range report.SpecReports {
+		if spec.LeafNodeType != types.NodeTypeIt {
+			continue
+		}
+		specData := htmlSpecData{
+			ContainerText: strings.Join(spec.ContainerHierarchyTexts, " › "),
+			LeafText:      spec.LeafNodeText,
+			Labels:        spec.Labels(),
+			Duration:      spec.RunTime.Round(time.Millisecond).String(),
+			Artifacts:     specArtifacts(spec),
+		}
+		switch spec.State {
+		case types.SpecStatePassed:
+			specData.State, specData.StateClass = "passed", "passed"
+			data.Passed++
+		case types.SpecStateSkipped, types.SpecStatePending:
+			specData.State, specData.StateClass = "skipped", "skipped"
+			data.Skipped++
+		default:
+			specData.State, specData.StateClass = spec.State.String(), "failed"
+			specData.Failure = formatSpecFailureWithTrace(spec)
+			data.Failed++
+		}
+		data.Specs = append(data.Specs, specData)
+	}
+	// Failures first, so a red run opens on what matters.
+	sort.SliceStable(data.Specs, func(i, j int) bool {
+		return data.Specs[i].StateClass == "failed" && data.Specs[j].StateClass != "failed"
+	})
+
+	parsed, err := template.New("report").Parse(htmlReportTemplate)
+	if err != nil {
+		return fmt.Errorf("failed to parse html report template: %w", err)
+	}
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create html report: %w", err)
+	}
+	defer file.Close()
+	if err := parsed.Execute(file, data); err != nil {
+		return fmt.Errorf("failed to render html report: %w", err)
+	}
+	return nil
+}
+
+func formatSpecFailureWithTrace(spec types.SpecReport) string {
+	var b strings.Builder
+	b.WriteString(spec.Failure.Message)
+	if location := spec.Failure.Location.String(); location != "" {
+		b.WriteString("\n\n")
+		b.WriteString(location)
+	}
+	if trace := spec.Failure.Location.FullStackTrace; trace != "" {
+		b.WriteString("\n\n")
+		b.WriteString(trace)
+	}
+	return b.String()
+}
+
+// specArtifacts collects report entry values that point at files or URLs,
+// the convention used by the diagnostics collectors.
+func specArtifacts(spec types.SpecReport) []string {
+	var artifacts []string
+	for _, entry := range spec.ReportEntries {
+		value := entry.Value.String()
+		if strings.HasPrefix(value, "/") || strings.HasPrefix(value, "./") ||
+			strings.HasPrefix(value, "http://") || strings.HasPrefix(value, "https://") {
+			artifacts = append(artifacts, value)
+		}
+	}
+	return artifacts
+}
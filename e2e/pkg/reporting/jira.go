@@ -0,0 +1,223 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package reporting
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/onsi/ginkgo/v2/types"
+)
+
+// JiraOptions configures bug drafting for triaged product failures.
+type JiraOptions struct {
+	// BaseURL is the JIRA server, e.g. "https://issues.redhat.com".
+	BaseURL string
+	// Token is a personal access token with create-issue permission.
+	Token string
+	// Project is the target project key, e.g. "ACM".
+	Project string
+	// Component and AffectsVersion are stamped on every drafted bug.
+	Component      string
+	AffectsVersion string
+	// DraftDir, when set, writes reviewable JSON drafts there instead of
+	// filing issues — the default posture, since auto-filed bugs without
+	// review erode trust in the queue.
+	DraftDir string
+}
+
+// BugDraft is the issue content derived from one failure.
+type BugDraft struct {
+	Summary     string   `json:"summary"`
+	Description string   `json:"description"`
+	Project     string   `json:"project"`
+	Component   string   `json:"component,omitempty"`
+	Version     string   `json:"version,omitempty"`
+	Labels      []string `json:"labels"`
+	// Attachments lists the artifact files to attach after creation.
+	Attachments []string `json:"attachments,omitempty"`
+}
+
+// DraftBugsFromTriage builds one bug draft per product-bug triage and
+// either files them or writes them to the draft directory. It returns
+// the created issue keys (or draft file paths).
+func DraftBugsFromTriage(ctx context.Context, opts JiraOptions, report types.Report, triages []Triage) ([]string, error) {
+	specsByName := map[string]types.SpecReport{}
+	for _, spec := range report.SpecReports {
+		specsByName[spec.FullText()] = spec
+	}
+
+	var created []string
+	for _, triage := range triages {
+		if triage.Class != TriageProductBug {
+			continue
+		}
+		spec, ok := specsByName[triage.SpecName]
+		if !ok {
+			continue
+		}
+		draft := buildBugDraft(opts, spec, triage)
+		if opts.DraftDir != "" {
+			path, err := writeBugDraft(opts.DraftDir, draft)
+			if err != nil {
+				return created, err
+			}
+			created = append(created, path)
+			continue
+		}
+		key, err := fileBug(ctx, opts, draft)
+		if err != nil {
+			return created, err
+		}
+		created = append(created, key)
+	}
+	return created, nil
+}
+
+func buildBugDraft(opts JiraOptions, spec types.SpecReport, triage Triage) BugDraft {
+	var description strings.Builder
+	description.WriteString("Automated e2e failure triaged as a product bug.\n\n")
+	fmt.Fprintf(&description, "*Triage rationale:* %s\n\n", triage.Rationale)
+	description.WriteString("*Steps to reproduce (from the spec):*\n")
+	for i, step := range spec.ContainerHierarchyTexts {
+		fmt.Fprintf(&description, "# %d. %s\n", i+1, step)
+	}
+	fmt.Fprintf(&description, "# %d. %s\n\n", len(spec.ContainerHierarchyTexts)+1, spec.LeafNodeText)
+	description.WriteString("*Failure:*\n{noformat}\n")
+	description.WriteString(truncateEvidence(spec.Failure.Message, 8000))
+	description.WriteString("\n{noformat}\n")
+	if location := spec.Failure.Location.String(); location != "" {
+		fmt.Fprintf(&description, "\nAt: %s\n", location)
+	}
+
+	summary := spec.LeafNodeText
+	if caseID := polarionCaseID(spec); caseID != "" {
+		summary = fmt.Sprintf("[%s] %s", caseID, summary)
+	}
+	labels := append([]string{"e2e-auto-triage"}, spec.Labels()...)
+	return BugDraft{
+		Summary:     truncateName(summary, 254),
+		Description: description.String(),
+		Project:     opts.Project,
+		Component:   opts.Component,
+		Version:     opts.AffectsVersion,
+		Labels:      labels,
+		Attachments: specArtifacts(spec),
+	}
+}
+
+func writeBugDraft(dir string, draft BugDraft) (string, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", fmt.Errorf("failed to create draft directory: %w", err)
+	}
+	encoded, err := json.MarshalIndent(draft, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to encode bug draft: %w", err)
+	}
+	path := filepath.Join(dir, sanitizeArtifactName(draft.Summary)+".json")
+	if err := os.WriteFile(path, encoded, 0o644); err != nil {
+		return "", fmt.Errorf("failed to write bug draft: %w", err)
+	}
+	return path, nil
+}
+
+func fileBug(ctx context.Context, opts JiraOptions, draft BugDraft) (string, error) {
+	fields := map[string]interface{}{
+		"project":     map[string]string{"key": draft.Project},
+		"issuetype":   map[string]string{"name": "Bug"},
+		"summary":     draft.Summary,
+		"description": draft.Description,
+		"labels":      draft.Labels,
+	}
+	if draft.Component != "" {
+		fields["components"] = []map[string]string{{"name": draft.Component}}
+	}
+	if draft.Version != "" {
+		fields["versions"] = []map[string]string{{"name": draft.Version}}
+	}
+	payload, err := json.Marshal(map[string]interface{}{"fields": fields})
+	if err != nil {
+		return "", err
+	}
+
+	url := strings.TrimRight(opts.BaseURL, "/") + "/rest/api/2/issue"
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(payload))
+	if err != nil {
+		return "", fmt.Errorf("failed to build jira request: %w", err)
+	}
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("Authorization", "Bearer "+opts.Token)
+
+	response, err := (&http.Client{Timeout: 30 * time.Second}).Do(request)
+	if err != nil {
+		return "", fmt.Errorf("failed to file jira bug: %w", err)
+	}
+	defer response.Body.Close()
+	body, err := io.ReadAll(io.LimitReader(response.Body, 1<<20))
+	if err != nil {
+		return "", fmt.Errorf("failed to read jira response: %w", err)
+	}
+	if response.StatusCode >= 300 {
+		return "", fmt.Errorf("jira answered %d: %s", response.StatusCode, string(body))
+	}
+	var parsed struct {
+		Key string `json:"key"`
+	}
+	if err := json.Unmarshal(body, &parsed); err != nil {
+		return "", fmt.Errorf("failed to parse jira response: %w", err)
+	}
+	for _, attachment := range draft.Attachments {
+		if err := attachFileToBug(ctx, opts, parsed.Key, attachment); err != nil {
+			// The bug exists; a failed attachment is reported, not fatal.
+			fmt.Fprintf(os.Stderr, "warning: failed to attach %s to %s: %v\n", attachment, parsed.Key, err)
+		}
+	}
+	return parsed.Key, nil
+}
+
+func attachFileToBug(ctx context.Context, opts JiraOptions, issueKey, path string) error {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	part, err := writer.CreateFormFile("file", filepath.Base(path))
+	if err != nil {
+		return err
+	}
+	if _, err := part.Write(content); err != nil {
+		return err
+	}
+	if err := writer.Close(); err != nil {
+		return err
+	}
+
+	url := fmt.Sprintf("%s/rest/api/2/issue/%s/attachments", strings.TrimRight(opts.BaseURL, "/"), issueKey)
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, url, &body)
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Content-Type", writer.FormDataContentType())
+	request.Header.Set("Authorization", "Bearer "+opts.Token)
+	request.Header.Set("X-Atlassian-Token", "no-check")
+
+	response, err := (&http.Client{Timeout: 60 * time.Second}).Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+	if response.StatusCode >= 300 {
+		return fmt.Errorf("attachment upload answered %d", response.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,109 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package reporting
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/onsi/ginkgo/v2/types"
+)
+
+// JSONSchemaVersion identifies the report layout. Consumers must check it
+// and reject versions they do not understand; fields are only added, and
+// removals or semantic changes bump the version.
+const JSONSchemaVersion = "1"
+
+// JSONReport is the stable, machine-readable run report consumed by
+// dashboards and the AI feedback loop.
+type JSONReport struct {
+	SchemaVersion string `json:"schemaVersion"`
+	Suite         string `json:"suite"`
+	StartedAt     string `json:"startedAt"`
+	// DurationSeconds is the wall-clock suite duration.
+	DurationSeconds float64 `json:"durationSeconds"`
+	Succeeded       bool    `json:"succeeded"`
+	// Environment is the manifest of what the run executed against:
+	// hub name, snapshot, cloud regions — whatever the caller records.
+	Environment map[string]string `json:"environment,omitempty"`
+	Specs       []JSONSpec        `json:"specs"`
+}
+
+// JSONSpec is one leaf spec's result.
+type JSONSpec struct {
+	Name       string   `json:"name"`
+	Containers []string `json:"containers,omitempty"`
+	Labels     []string `json:"labels,omitempty"`
+	// CaseID is the RHACM4K label, when the spec carries one.
+	CaseID          string   `json:"caseId,omitempty"`
+	State           string   `json:"state"`
+	DurationSeconds float64  `json:"durationSeconds"`
+	Failure         string   `json:"failure,omitempty"`
+	FailureLocation string   `json:"failureLocation,omitempty"`
+	Artifacts       []string `json:"artifacts,omitempty"`
+}
+
+// BuildJSONReport converts a Ginkgo report into the schema.
+func BuildJSONReport(report types.Report, environment map[string]string) *JSONReport {
+	jsonReport := &JSONReport{
+		SchemaVersion:   JSONSchemaVersion,
+		Suite:           report.SuiteDescription,
+		StartedAt:       report.StartTime.UTC().Format(time.RFC3339),
+		DurationSeconds: report.RunTime.Seconds(),
+		Succeeded:       report.SuiteSucceeded,
+		Environment:     environment,
+	}
+	for _, spec := range report.SpecReports {
+		if spec.LeafNodeType != types.NodeTypeIt {
+			continue
+		}
+		jsonSpec := JSONSpec{
+			Name:            spec.FullText(),
+			Containers:      spec.ContainerHierarchyTexts,
+			Labels:          spec.Labels(),
+			CaseID:          polarionCaseID(spec),
+			State:           spec.State.String(),
+			DurationSeconds: spec.RunTime.Seconds(),
+			Artifacts:       specArtifacts(spec),
+		}
+		if spec.State != types.SpecStatePassed && spec.State != types.SpecStateSkipped &&
+			spec.State != types.SpecStatePending {
+			jsonSpec.Failure = spec.Failure.Message
+			jsonSpec.FailureLocation = spec.Failure.Location.String()
+		}
+		jsonReport.Specs = append(jsonReport.Specs, jsonSpec)
+	}
+	return jsonReport
+}
+
+// WriteJSONReport writes the schema'd report to path.
+func WriteJSONReport(report types.Report, environment map[string]string, path string) error {
+	encoded, err := json.MarshalIndent(BuildJSONReport(report, environment), "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode json report: %w", err)
+	}
+	if err := os.WriteFile(path, encoded, 0o644); err != nil {
+		return fmt.Errorf("failed to write json report: %w", err)
+	}
+	return nil
+}
+
+// ReadJSONReport loads a report written by WriteJSONReport, rejecting
+// unknown schema versions.
+func ReadJSONReport(path string) (*JSONReport, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read json report %s: %w", path, err)
+	}
+	report := &JSONReport{}
+	if err := json.Unmarshal(content, report); err != nil {
+		return nil, fmt.Errorf("failed to parse json report %s: %w", path, err)
+	}
+	if report.SchemaVersion != JSONSchemaVersion {
+		return nil, fmt.Errorf("json report %s has schema version %q, this build understands %q",
+			path, report.SchemaVersion, JSONSchemaVersion)
+	}
+	return report, nil
+}
@@ -0,0 +1,80 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package reporting
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/onsi/ginkgo/v2/types"
+)
+
+func TestJSONReportRoundTrip(t *testing.T) {
+	report := types.Report{
+		SuiteDescription: "CLC provisioning",
+		SuiteSucceeded:   false,
+		StartTime:        time.Date(2026, 8, 30, 12, 0, 0, 0, time.UTC),
+		RunTime:          90 * time.Second,
+		SpecReports: types.SpecReports{
+			{
+				LeafNodeType:            types.NodeTypeIt,
+				State:                   types.SpecStateFailed,
+				ContainerHierarchyTexts: []string{"Import"},
+				LeafNodeText:            "imports the cluster",
+				LeafNodeLabels:          []string{"RHACM4K-2", "aws"},
+				RunTime:                 30 * time.Second,
+				Failure:                 types.Failure{Message: "never joined"},
+			},
+			{
+				LeafNodeType: types.NodeTypeIt,
+				State:        types.SpecStatePassed,
+				LeafNodeText: "provisions the cluster",
+				RunTime:      60 * time.Second,
+			},
+			{
+				LeafNodeType: types.NodeTypeBeforeSuite,
+				State:        types.SpecStatePassed,
+			},
+		},
+	}
+	environment := map[string]string{"hub": "hub1", "snapshot": "2.15.0-DOWNSTREAM-2026-08-29"}
+
+	path := filepath.Join(t.TempDir(), "report.json")
+	if err := WriteJSONReport(report, environment, path); err != nil {
+		t.Fatalf("WriteJSONReport: %v", err)
+	}
+	parsed, err := ReadJSONReport(path)
+	if err != nil {
+		t.Fatalf("ReadJSONReport: %v", err)
+	}
+
+	if parsed.SchemaVersion != JSONSchemaVersion || parsed.Suite != "CLC provisioning" || parsed.Succeeded {
+		t.Errorf("header = %+v", parsed)
+	}
+	if parsed.Environment["snapshot"] != environment["snapshot"] {
+		t.Errorf("environment = %v", parsed.Environment)
+	}
+	if len(parsed.Specs) != 2 {
+		t.Fatalf("got %d specs, want 2 (leaves only)", len(parsed.Specs))
+	}
+	failed := parsed.Specs[0]
+	if failed.CaseID != "RHACM4K-2" || failed.State != types.SpecStateFailed.String() ||
+		failed.Failure != "never joined" || failed.DurationSeconds != 30 {
+		t.Errorf("failed spec = %+v", failed)
+	}
+	if passed := parsed.Specs[1]; passed.Failure != "" || passed.State != types.SpecStatePassed.String() {
+		t.Errorf("passed spec = %+v", passed)
+	}
+}
+
+func TestReadJSONReportRejectsUnknownSchema(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "report.json")
+	if err := os.WriteFile(path, []byte(`{"schemaVersion":"99","suite":"x"}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ReadJSONReport(path); err == nil {
+		t.Fatal("unknown schema version was accepted")
+	}
+}
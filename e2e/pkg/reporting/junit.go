@@ -0,0 +1,119 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package reporting
+
+import (
+	"encoding/xml"
+	"fmt"
+	"os"
+	"sort"
+)
+
+// JUnit document model shared by the merge, flake-analysis and
+// log-attachment helpers. Only the fields our CI consumers read are
+// modeled.
+type JUnitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Errors   int              `xml:"errors,attr"`
+	Time     float64          `xml:"time,attr"`
+	Suites   []JUnitTestSuite `xml:"testsuite"`
+}
+
+type JUnitTestSuite struct {
+	Name     string          `xml:"name,attr"`
+	Tests    int             `xml:"tests,attr"`
+	Failures int             `xml:"failures,attr"`
+	Errors   int             `xml:"errors,attr"`
+	Skipped  int             `xml:"skipped,attr"`
+	Time     float64         `xml:"time,attr"`
+	Cases    []JUnitTestCase `xml:"testcase"`
+}
+
+type JUnitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	Classname string        `xml:"classname,attr"`
+	Time      float64       `xml:"time,attr"`
+	Failure   *JUnitMessage `xml:"failure,omitempty"`
+	Error     *JUnitMessage `xml:"error,omitempty"`
+	Skipped   *JUnitMessage `xml:"skipped,omitempty"`
+	SystemOut string        `xml:"system-out,omitempty"`
+}
+
+type JUnitMessage struct {
+	Message string `xml:"message,attr,omitempty"`
+	Body    string `xml:",chardata"`
+}
+
+// ReadJUnitFile parses a JUnit report, accepting both a <testsuites>
+// document and a bare top-level <testsuite>, since Ginkgo and other
+// producers disagree on the wrapper.
+func ReadJUnitFile(path string) (*JUnitTestSuites, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read junit report %s: %w", path, err)
+	}
+	suites := &JUnitTestSuites{}
+	if err := xml.Unmarshal(content, suites); err == nil && len(suites.Suites) > 0 {
+		return suites, nil
+	}
+	suite := JUnitTestSuite{}
+	if err := xml.Unmarshal(content, &suite); err != nil {
+		return nil, fmt.Errorf("failed to parse junit report %s: %w", path, err)
+	}
+	return &JUnitTestSuites{Suites: []JUnitTestSuite{suite}}, nil
+}
+
+// WriteJUnitFile serializes the document with recomputed top-level
+// counters.
+func WriteJUnitFile(suites *JUnitTestSuites, path string) error {
+	suites.Tests, suites.Failures, suites.Errors, suites.Time = 0, 0, 0, 0
+	for _, suite := range suites.Suites {
+		suites.Tests += suite.Tests
+		suites.Failures += suite.Failures
+		suites.Errors += suite.Errors
+		suites.Time += suite.Time
+	}
+	encoded, err := xml.MarshalIndent(suites, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode junit report: %w", err)
+	}
+	content := append([]byte(xml.Header), encoded...)
+	if err := os.WriteFile(path, content, 0o644); err != nil {
+		return fmt.Errorf("failed to write junit report %s: %w", path, err)
+	}
+	return nil
+}
+
+// MergeJUnitFiles combines per-suite JUnit files into one consolidated
+// report. The map keys become suite name prefixes ("create", "import",
+// ...), so the merged report keeps the specs attributable when CI runs
+// the lifecycle stages as separate invocations.
+func MergeJUnitFiles(inputs map[string]string, outputPath string) error {
+	if len(inputs) == 0 {
+		return fmt.Errorf("no junit reports to merge")
+	}
+	prefixes := make([]string, 0, len(inputs))
+	for prefix := range inputs {
+		prefixes = append(prefixes, prefix)
+	}
+	sort.Strings(prefixes)
+
+	merged := &JUnitTestSuites{}
+	for _, prefix := range prefixes {
+		parsed, err := ReadJUnitFile(inputs[prefix])
+		if err != nil {
+			return err
+		}
+		for _, suite := range parsed.Suites {
+			if suite.Name == "" {
+				suite.Name = prefix
+			} else {
+				suite.Name = prefix + "/" + suite.Name
+			}
+			merged.Suites = append(merged.Suites, suite)
+		}
+	}
+	return WriteJUnitFile(merged, outputPath)
+}
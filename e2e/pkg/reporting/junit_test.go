@@ -0,0 +1,117 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package reporting
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeTestJUnit(t *testing.T, name string, suites *JUnitTestSuites) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := WriteJUnitFile(suites, path); err != nil {
+		t.Fatalf("WriteJUnitFile: %v", err)
+	}
+	return path
+}
+
+func TestJUnitRoundTrip(t *testing.T) {
+	original := &JUnitTestSuites{Suites: []JUnitTestSuite{{
+		Name:     "create",
+		Tests:    2,
+		Failures: 1,
+		Time:     12.5,
+		Cases: []JUnitTestCase{
+			{Name: "RHACM4K-1 provisions", Classname: "create", Time: 10},
+			{
+				Name: "RHACM4K-2 imports", Classname: "create", Time: 2.5,
+				Failure: &JUnitMessage{Message: "timed out", Body: "cluster never joined"},
+			},
+		},
+	}}}
+	path := writeTestJUnit(t, "junit.xml", original)
+
+	parsed, err := ReadJUnitFile(path)
+	if err != nil {
+		t.Fatalf("ReadJUnitFile: %v", err)
+	}
+	if len(parsed.Suites) != 1 || len(parsed.Suites[0].Cases) != 2 {
+		t.Fatalf("round trip lost cases: %+v", parsed)
+	}
+	// WriteJUnitFile recomputes the top-level counters from the suites.
+	if parsed.Tests != 2 || parsed.Failures != 1 {
+		t.Errorf("top-level counters = %d tests / %d failures, want 2 / 1", parsed.Tests, parsed.Failures)
+	}
+	failure := parsed.Suites[0].Cases[1].Failure
+	if failure == nil || failure.Message != "timed out" || failure.Body != "cluster never joined" {
+		t.Errorf("failure did not survive the round trip: %+v", failure)
+	}
+}
+
+func TestReadJUnitFileBareTestsuite(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "bare.xml")
+	content := `<?xml version="1.0"?>
+<testsuite name="import" tests="1">
+  <testcase name="RHACM4K-3 detaches" classname="import" time="1.5"/>
+</testsuite>`
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	parsed, err := ReadJUnitFile(path)
+	if err != nil {
+		t.Fatalf("ReadJUnitFile: %v", err)
+	}
+	if len(parsed.Suites) != 1 || parsed.Suites[0].Name != "import" {
+		t.Fatalf("bare testsuite not wrapped: %+v", parsed)
+	}
+}
+
+func TestReadJUnitFileRejectsGarbage(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "garbage.xml")
+	if err := os.WriteFile(path, []byte("not xml at all"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ReadJUnitFile(path); err == nil {
+		t.Fatal("ReadJUnitFile accepted garbage input")
+	}
+}
+
+func TestMergeJUnitFiles(t *testing.T) {
+	createPath := writeTestJUnit(t, "create.xml", &JUnitTestSuites{Suites: []JUnitTestSuite{{
+		Name: "e2e", Tests: 1, Time: 5,
+		Cases: []JUnitTestCase{{Name: "RHACM4K-1 provisions", Time: 5}},
+	}}})
+	importPath := writeTestJUnit(t, "import.xml", &JUnitTestSuites{Suites: []JUnitTestSuite{{
+		Tests: 1, Failures: 1, Time: 2,
+		Cases: []JUnitTestCase{{Name: "RHACM4K-2 imports", Failure: &JUnitMessage{Message: "boom"}}},
+	}}})
+
+	outputPath := filepath.Join(t.TempDir(), "merged.xml")
+	err := MergeJUnitFiles(map[string]string{"create": createPath, "import": importPath}, outputPath)
+	if err != nil {
+		t.Fatalf("MergeJUnitFiles: %v", err)
+	}
+
+	merged, err := ReadJUnitFile(outputPath)
+	if err != nil {
+		t.Fatalf("ReadJUnitFile: %v", err)
+	}
+	if len(merged.Suites) != 2 {
+		t.Fatalf("merged %d suites, want 2", len(merged.Suites))
+	}
+	// Prefixes are applied in sorted order; an unnamed suite takes the
+	// prefix as its name.
+	if merged.Suites[0].Name != "create/e2e" || merged.Suites[1].Name != "import" {
+		t.Errorf("suite names = %q, %q; want create/e2e, import", merged.Suites[0].Name, merged.Suites[1].Name)
+	}
+	if merged.Tests != 2 || merged.Failures != 1 {
+		t.Errorf("merged counters = %d tests / %d failures, want 2 / 1", merged.Tests, merged.Failures)
+	}
+
+	if err := MergeJUnitFiles(nil, outputPath); err == nil {
+		t.Error("MergeJUnitFiles accepted an empty input set")
+	}
+}
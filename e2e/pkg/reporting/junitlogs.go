@@ -0,0 +1,71 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package reporting
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/onsi/ginkgo/v2/types"
+)
+
+// junitLogLimit caps embedded output per test case; CI JUnit viewers
+// choke on multi-megabyte system-out blocks.
+const junitLogLimit = 64 * 1024
+
+// EmbedFailureLogs enriches failed JUnit test cases with the context
+// captured at failure time: spec output goes into system-out, and the
+// event dump recorded by the diagnostics hooks is appended to the
+// failure body. CI systems that only surface JUnit then show the same
+// context as the HTML report.
+func EmbedFailureLogs(suites *JUnitTestSuites, report types.Report) {
+	specsByName := map[string]types.SpecReport{}
+	for _, spec := range report.SpecReports {
+		if spec.LeafNodeType != types.NodeTypeIt {
+			continue
+		}
+		specsByName[spec.FullText()] = spec
+	}
+
+	for i := range suites.Suites {
+		for j := range suites.Suites[i].Cases {
+			testcase := &suites.Suites[i].Cases[j]
+			if testcase.Failure == nil && testcase.Error == nil {
+				continue
+			}
+			spec, ok := specsByName[testcase.Name]
+			if !ok {
+				continue
+			}
+			embedSpecContext(testcase, spec)
+		}
+	}
+}
+
+func embedSpecContext(testcase *JUnitTestCase, spec types.SpecReport) {
+	if output := spec.CapturedGinkgoWriterOutput; output != "" {
+		block := "captured output:\n" + truncateEvidence(output, junitLogLimit)
+		if testcase.SystemOut == "" {
+			testcase.SystemOut = block
+		} else {
+			testcase.SystemOut += "\n" + block
+		}
+	}
+
+	var sections []string
+	if events := reportEntryValue(spec, "events"); events != "" {
+		sections = append(sections, "events at failure:\n"+truncateEvidence(events, junitLogLimit))
+	}
+	if artifacts := specArtifacts(spec); len(artifacts) > 0 {
+		sections = append(sections, "artifacts:\n"+strings.Join(artifacts, "\n"))
+	}
+	if len(sections) == 0 {
+		return
+	}
+	annotation := fmt.Sprintf("\n\n%s", strings.Join(sections, "\n\n"))
+	if testcase.Failure != nil {
+		testcase.Failure.Body += annotation
+	} else {
+		testcase.Error.Body += annotation
+	}
+}
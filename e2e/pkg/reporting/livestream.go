@@ -0,0 +1,146 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package reporting
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/onsi/ginkgo/v2/types"
+)
+
+// LiveEvent is one progress event streamed to dashboard clients.
+type LiveEvent struct {
+	// Type is "suite-started", "spec-started", "spec-finished" or
+	// "suite-finished".
+	Type string `json:"type"`
+	Time string `json:"time"`
+	Spec string `json:"spec,omitempty"`
+	// State is set on spec-finished and suite-finished events.
+	State           string  `json:"state,omitempty"`
+	DurationSeconds float64 `json:"durationSeconds,omitempty"`
+}
+
+// LiveStream broadcasts suite progress over Server-Sent Events. SSE
+// works through plain HTTP proxies and needs no client library, which is
+// why it is preferred over WebSocket here; the event payloads are
+// identical either way. New subscribers first receive a replay of all
+// events so far, so a dashboard attached mid-run still renders complete
+// progress.
+type LiveStream struct {
+	mu          sync.Mutex
+	history     []LiveEvent
+	subscribers map[chan LiveEvent]struct{}
+}
+
+// NewLiveStream returns an empty broadcaster.
+func NewLiveStream() *LiveStream {
+	return &LiveStream{subscribers: map[chan LiveEvent]struct{}{}}
+}
+
+// Publish records and broadcasts an event. Slow subscribers are skipped
+// rather than allowed to block the suite.
+func (s *LiveStream) Publish(event LiveEvent) {
+	event.Time = time.Now().UTC().Format(time.RFC3339)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.history = append(s.history, event)
+	for subscriber := range s.subscribers {
+		select {
+		case subscriber <- event:
+		default:
+		}
+	}
+}
+
+// SuiteStarted publishes the run's opening event.
+func (s *LiveStream) SuiteStarted(description string) {
+	s.Publish(LiveEvent{Type: "suite-started", Spec: description})
+}
+
+// SpecStarted publishes a spec-started event; wire it to a
+// ReportBeforeEach node.
+func (s *LiveStream) SpecStarted(spec types.SpecReport) {
+	s.Publish(LiveEvent{Type: "spec-started", Spec: spec.FullText()})
+}
+
+// SpecFinished publishes a spec result; wire it to a ReportAfterEach
+// node.
+func (s *LiveStream) SpecFinished(spec types.SpecReport) {
+	s.Publish(LiveEvent{
+		Type:            "spec-finished",
+		Spec:            spec.FullText(),
+		State:           spec.State.String(),
+		DurationSeconds: spec.RunTime.Seconds(),
+	})
+}
+
+// SuiteFinished publishes the final verdict; wire it to ReportAfterSuite.
+func (s *LiveStream) SuiteFinished(report types.Report) {
+	state := "passed"
+	if !report.SuiteSucceeded {
+		state = "failed"
+	}
+	s.Publish(LiveEvent{
+		Type:            "suite-finished",
+		Spec:            report.SuiteDescription,
+		State:           state,
+		DurationSeconds: report.RunTime.Seconds(),
+	})
+}
+
+// ServeHTTP implements the SSE endpoint; mount it on the server-mode mux,
+// e.g. at /events.
+func (s *LiveStream) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming unsupported", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+
+	subscriber := make(chan LiveEvent, 64)
+	s.mu.Lock()
+	replay := make([]LiveEvent, len(s.history))
+	copy(replay, s.history)
+	s.subscribers[subscriber] = struct{}{}
+	s.mu.Unlock()
+	defer func() {
+		s.mu.Lock()
+		delete(s.subscribers, subscriber)
+		s.mu.Unlock()
+	}()
+
+	for _, event := range replay {
+		if err := writeSSEEvent(w, event); err != nil {
+			return
+		}
+	}
+	flusher.Flush()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case event := <-subscriber:
+			if err := writeSSEEvent(w, event); err != nil {
+				return
+			}
+			flusher.Flush()
+		}
+	}
+}
+
+func writeSSEEvent(w http.ResponseWriter, event LiveEvent) error {
+	encoded, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event.Type, encoded)
+	return err
+}
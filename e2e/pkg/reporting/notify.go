@@ -0,0 +1,147 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package reporting
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/onsi/ginkgo/v2/types"
+)
+
+// Notification environment variables; either webhook may be set, or both.
+const (
+	slackWebhookEnv = "SLACK_WEBHOOK_URL"
+	teamsWebhookEnv = "TEAMS_WEBHOOK_URL"
+	artifactsURLEnv = "ARTIFACTS_URL"
+)
+
+// NotifierOptions configures the suite-completion notification.
+type NotifierOptions struct {
+	SlackWebhookURL string
+	TeamsWebhookURL string
+	// ArtifactsURL is linked at the end of the message, typically the CI
+	// job's artifact browser.
+	ArtifactsURL string
+	// OnlyOnFailure suppresses the message for green runs.
+	OnlyOnFailure bool
+}
+
+// NotifierFromEnv reads the webhook configuration from the environment.
+func NotifierFromEnv() NotifierOptions {
+	return NotifierOptions{
+		SlackWebhookURL: os.Getenv(slackWebhookEnv),
+		TeamsWebhookURL: os.Getenv(teamsWebhookEnv),
+		ArtifactsURL:    os.Getenv(artifactsURLEnv),
+	}
+}
+
+// NotifySuiteCompletion posts a pass/fail summary with a per-label
+// breakdown to the configured webhooks. Register it in ReportAfterSuite;
+// it is a no-op when no webhook is configured.
+func NotifySuiteCompletion(ctx context.Context, opts NotifierOptions, report types.Report) error {
+	if opts.SlackWebhookURL == "" && opts.TeamsWebhookURL == "" {
+		return nil
+	}
+	if opts.OnlyOnFailure && report.SuiteSucceeded {
+		return nil
+	}
+	message := formatSuiteSummary(report, opts.ArtifactsURL)
+
+	if opts.SlackWebhookURL != "" {
+		if err := postWebhook(ctx, opts.SlackWebhookURL, map[string]string{"text": message}); err != nil {
+			return fmt.Errorf("failed to notify slack: %w", err)
+		}
+	}
+	if opts.TeamsWebhookURL != "" {
+		// Teams incoming webhooks accept the same minimal text payload.
+		if err := postWebhook(ctx, opts.TeamsWebhookURL, map[string]string{"text": message}); err != nil {
+			return fmt.Errorf("failed to notify teams: %w", err)
+		}
+	}
+	return nil
+}
+
+func formatSuiteSummary(report types.Report, artifactsURL string) string {
+	var passed, failed, skipped int
+	labelTotals := map[string]*[2]int{} // label -> [passed, failed]
+	for _, spec := range report.SpecReports {
+		if spec.LeafNodeType != types.NodeTypeIt {
+			continue
+		}
+		switch spec.State {
+		case types.SpecStatePassed:
+			passed++
+			countLabels(labelTotals, spec.Labels(), 0)
+		case types.SpecStateSkipped, types.SpecStatePending:
+			skipped++
+		default:
+			failed++
+			countLabels(labelTotals, spec.Labels(), 1)
+		}
+	}
+
+	verdict := "PASSED"
+	if !report.SuiteSucceeded {
+		verdict = "FAILED"
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s %s — %d passed, %d failed, %d skipped in %s\n",
+		report.SuiteDescription, verdict, passed, failed, skipped, report.RunTime.Round(time.Second))
+
+	labels := make([]string, 0, len(labelTotals))
+	for label := range labelTotals {
+		labels = append(labels, label)
+	}
+	sort.Strings(labels)
+	for _, label := range labels {
+		totals := labelTotals[label]
+		if totals[1] == 0 {
+			continue
+		}
+		fmt.Fprintf(&b, "  %s: %d failed of %d\n", label, totals[1], totals[0]+totals[1])
+	}
+	if artifactsURL != "" {
+		fmt.Fprintf(&b, "artifacts: %s", artifactsURL)
+	}
+	return b.String()
+}
+
+func countLabels(totals map[string]*[2]int, labels []string, slot int) {
+	for _, label := range labels {
+		if totals[label] == nil {
+			totals[label] = &[2]int{}
+		}
+		totals[label][slot]++
+	}
+}
+
+func postWebhook(ctx context.Context, url string, payload interface{}) error {
+	encoded, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(encoded))
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Content-Type", "application/json")
+	response, err := (&http.Client{Timeout: 15 * time.Second}).Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+	if response.StatusCode >= 300 {
+		body, _ := io.ReadAll(io.LimitReader(response.Body, 4096))
+		return fmt.Errorf("webhook answered %d: %s", response.StatusCode, string(body))
+	}
+	return nil
+}
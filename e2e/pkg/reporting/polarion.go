@@ -0,0 +1,172 @@
+// Copyright Contributors to the Open Cluster Management project
+
+// Package reporting turns Ginkgo suite reports into the formats our CI
+// and test-management tooling consume, beyond the built-in JUnit output.
+package reporting
+
+import (
+	"encoding/xml"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/onsi/ginkgo/v2/types"
+)
+
+// polarionIDPattern matches the RHACM4K test case IDs carried as spec
+// labels, e.g. Label("RHACM4K-7473").
+var polarionIDPattern = regexp.MustCompile(`^RHACM4K-\d+$`)
+
+// PolarionOptions configures the Polarion importer output.
+type PolarionOptions struct {
+	// ProjectID is the Polarion project, e.g. "RHACM4K".
+	ProjectID string
+	// TestRunID names the test run created by the importer. When empty a
+	// run ID is derived from the suite name and start time.
+	TestRunID string
+	// IncludeSkipped also reports skipped specs as blocked instead of
+	// omitting them.
+	IncludeSkipped bool
+}
+
+type polarionTestsuites struct {
+	XMLName    xml.Name           `xml:"testsuites"`
+	Properties polarionProperties `xml:"properties"`
+	Suites     []polarionSuite    `xml:"testsuite"`
+}
+
+type polarionProperties struct {
+	Properties []polarionProperty `xml:"property"`
+}
+
+type polarionProperty struct {
+	Name  string `xml:"name,attr"`
+	Value string `xml:"value,attr"`
+}
+
+type polarionSuite struct {
+	Name     string             `xml:"name,attr"`
+	Tests    int                `xml:"tests,attr"`
+	Failures int                `xml:"failures,attr"`
+	Skipped  int                `xml:"skipped,attr"`
+	Time     float64            `xml:"time,attr"`
+	Cases    []polarionTestcase `xml:"testcase"`
+}
+
+type polarionTestcase struct {
+	Name       string             `xml:"name,attr"`
+	Time       float64            `xml:"time,attr"`
+	Properties polarionProperties `xml:"properties"`
+	Failure    *polarionMessage   `xml:"failure,omitempty"`
+	Skipped    *polarionMessage   `xml:"skipped,omitempty"`
+}
+
+type polarionMessage struct {
+	Message string `xml:",chardata"`
+}
+
+// WritePolarionReport renders the Ginkgo report as Polarion
+// xunit-importer XML. Specs without an RHACM4K label are left out — they
+// have no test case to report against.
+func WritePolarionReport(report types.Report, opts PolarionOptions, path string) error {
+	document, err := buildPolarionDocument(report, opts)
+	if err != nil {
+		return err
+	}
+	encoded, err := xml.MarshalIndent(document, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode polarion report: %w", err)
+	}
+	content := append([]byte(xml.Header), encoded...)
+	if err := os.WriteFile(path, content, 0o644); err != nil {
+		return fmt.Errorf("failed to write polarion report: %w", err)
+	}
+	return nil
+}
+
+// buildPolarionDocument assembles the importer document; shared by the
+// file exporter and the API uploader.
+func buildPolarionDocument(report types.Report, opts PolarionOptions) (*polarionTestsuites, error) {
+	if opts.ProjectID == "" {
+		return nil, fmt.Errorf("polarion project ID is required")
+	}
+	testRunID := opts.TestRunID
+	if testRunID == "" {
+		testRunID = fmt.Sprintf("%s-%s",
+			sanitizeRunID(report.SuiteDescription), report.StartTime.Format("20060102-150405"))
+	}
+
+	suite := polarionSuite{Name: report.SuiteDescription}
+	for _, spec := range report.SpecReports {
+		if spec.LeafNodeType != types.NodeTypeIt {
+			continue
+		}
+		caseID := polarionCaseID(spec)
+		if caseID == "" {
+			continue
+		}
+		testcase := polarionTestcase{
+			Name: spec.FullText(),
+			Time: spec.RunTime.Seconds(),
+			Properties: polarionProperties{Properties: []polarionProperty{
+				{Name: "polarion-testcase-id", Value: caseID},
+			}},
+		}
+		switch spec.State {
+		case types.SpecStatePassed:
+		case types.SpecStateSkipped, types.SpecStatePending:
+			if !opts.IncludeSkipped {
+				continue
+			}
+			testcase.Skipped = &polarionMessage{Message: spec.Failure.Message}
+			suite.Skipped++
+		default:
+			testcase.Failure = &polarionMessage{Message: formatSpecFailure(spec)}
+			suite.Failures++
+		}
+		suite.Cases = append(suite.Cases, testcase)
+		suite.Tests++
+		suite.Time += spec.RunTime.Seconds()
+	}
+
+	return &polarionTestsuites{
+		Properties: polarionProperties{Properties: []polarionProperty{
+			{Name: "polarion-project-id", Value: opts.ProjectID},
+			{Name: "polarion-testrun-id", Value: testRunID},
+			{Name: "polarion-lookup-method", Value: "custom"},
+		}},
+		Suites: []polarionSuite{suite},
+	}, nil
+}
+
+// polarionCaseID returns the spec's RHACM4K label, or empty when it has
+// none.
+func polarionCaseID(spec types.SpecReport) string {
+	for _, label := range spec.Labels() {
+		if polarionIDPattern.MatchString(label) {
+			return label
+		}
+	}
+	return ""
+}
+
+func formatSpecFailure(spec types.SpecReport) string {
+	if spec.Failure.Message == "" {
+		return spec.State.String()
+	}
+	return fmt.Sprintf("%s\n%s", spec.Failure.Message, spec.Failure.Location.String())
+}
+
+func sanitizeRunID(name string) string {
+	// Polarion run IDs reject spaces and most punctuation.
+	replaced := strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '-', r == '_':
+			return r
+		default:
+			return '-'
+		}
+	}, name)
+	return strings.Trim(replaced, "-")
+}
@@ -0,0 +1,124 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package reporting
+
+import (
+	"testing"
+	"time"
+
+	"github.com/onsi/ginkgo/v2/types"
+)
+
+func polarionTestReport() types.Report {
+	return types.Report{
+		SuiteDescription: "CLC provisioning",
+		StartTime:        time.Date(2026, 8, 30, 12, 0, 0, 0, time.UTC),
+		SpecReports: types.SpecReports{
+			{
+				LeafNodeType:   types.NodeTypeIt,
+				State:          types.SpecStatePassed,
+				LeafNodeText:   "provisions on AWS",
+				LeafNodeLabels: []string{"RHACM4K-7473", "aws"},
+				RunTime:        90 * time.Second,
+			},
+			{
+				LeafNodeType:   types.NodeTypeIt,
+				State:          types.SpecStateFailed,
+				LeafNodeText:   "imports the cluster",
+				LeafNodeLabels: []string{"RHACM4K-7474"},
+				RunTime:        30 * time.Second,
+				Failure:        types.Failure{Message: "never joined"},
+			},
+			{
+				// No RHACM4K label: nothing to report against.
+				LeafNodeType: types.NodeTypeIt,
+				State:        types.SpecStatePassed,
+				LeafNodeText: "unlabelled helper spec",
+			},
+			{
+				LeafNodeType:   types.NodeTypeIt,
+				State:          types.SpecStateSkipped,
+				LeafNodeText:   "skipped on this profile",
+				LeafNodeLabels: []string{"RHACM4K-7475"},
+			},
+		},
+	}
+}
+
+func TestBuildPolarionDocument(t *testing.T) {
+	document, err := buildPolarionDocument(polarionTestReport(), PolarionOptions{
+		ProjectID: "RHACM4K",
+		TestRunID: "nightly-123",
+	})
+	if err != nil {
+		t.Fatalf("buildPolarionDocument: %v", err)
+	}
+
+	properties := map[string]string{}
+	for _, property := range document.Properties.Properties {
+		properties[property.Name] = property.Value
+	}
+	if properties["polarion-project-id"] != "RHACM4K" || properties["polarion-testrun-id"] != "nightly-123" {
+		t.Errorf("importer properties = %v", properties)
+	}
+
+	if len(document.Suites) != 1 {
+		t.Fatalf("got %d suites, want 1", len(document.Suites))
+	}
+	suite := document.Suites[0]
+	// The unlabelled spec is dropped; the skipped one too without
+	// IncludeSkipped.
+	if suite.Tests != 2 || len(suite.Cases) != 2 {
+		t.Fatalf("suite has %d cases (tests=%d), want 2", len(suite.Cases), suite.Tests)
+	}
+	if suite.Failures != 1 {
+		t.Errorf("suite failures = %d, want 1", suite.Failures)
+	}
+	if got := suite.Cases[0].Properties.Properties[0]; got.Name != "polarion-testcase-id" || got.Value != "RHACM4K-7473" {
+		t.Errorf("first case property = %+v", got)
+	}
+	if suite.Cases[1].Failure == nil {
+		t.Error("failed spec has no failure message")
+	}
+}
+
+func TestBuildPolarionDocumentIncludeSkipped(t *testing.T) {
+	document, err := buildPolarionDocument(polarionTestReport(), PolarionOptions{
+		ProjectID:      "RHACM4K",
+		IncludeSkipped: true,
+	})
+	if err != nil {
+		t.Fatalf("buildPolarionDocument: %v", err)
+	}
+	suite := document.Suites[0]
+	if suite.Tests != 3 || suite.Skipped != 1 {
+		t.Errorf("with IncludeSkipped: tests=%d skipped=%d, want 3/1", suite.Tests, suite.Skipped)
+	}
+}
+
+func TestBuildPolarionDocumentRequiresProject(t *testing.T) {
+	if _, err := buildPolarionDocument(polarionTestReport(), PolarionOptions{}); err == nil {
+		t.Fatal("missing project ID was accepted")
+	}
+}
+
+func TestPolarionCaseID(t *testing.T) {
+	spec := types.SpecReport{LeafNodeLabels: []string{"aws", "RHACM4K-42"}}
+	if got := polarionCaseID(spec); got != "RHACM4K-42" {
+		t.Errorf("polarionCaseID = %q, want RHACM4K-42", got)
+	}
+	// The label must be the ID alone, not merely contain one.
+	spec = types.SpecReport{LeafNodeLabels: []string{"see RHACM4K-42", "gcp"}}
+	if got := polarionCaseID(spec); got != "" {
+		t.Errorf("polarionCaseID = %q, want empty", got)
+	}
+}
+
+func TestSanitizeRunID(t *testing.T) {
+	if got := sanitizeRunID("CLC provisioning (nightly)"); got != "CLC-provisioning--nightly" {
+		t.Errorf("sanitizeRunID = %q", got)
+	}
+	if got := sanitizeRunID("--already-clean_1--"); got != "already-clean_1" {
+		t.Errorf("sanitizeRunID = %q", got)
+	}
+}
@@ -0,0 +1,132 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package reporting
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/onsi/ginkgo/v2/types"
+)
+
+// Polarion uploader environment variables.
+const (
+	polarionURLEnv     = "POLARION_URL"
+	polarionTokenEnv   = "POLARION_TOKEN"
+	polarionProjectEnv = "POLARION_PROJECT"
+)
+
+// PolarionUploader posts run results straight to the Polarion xunit
+// importer, closing the loop the XML exporter only prepares.
+type PolarionUploader struct {
+	baseURL    string
+	token      string
+	httpClient *http.Client
+}
+
+// NewPolarionUploaderFromEnv builds an uploader from POLARION_*
+// variables, returning nil when POLARION_URL is unset.
+func NewPolarionUploaderFromEnv() (*PolarionUploader, error) {
+	baseURL := os.Getenv(polarionURLEnv)
+	if baseURL == "" {
+		return nil, nil
+	}
+	token := os.Getenv(polarionTokenEnv)
+	if token == "" {
+		return nil, fmt.Errorf("%s is set but %s is missing", polarionURLEnv, polarionTokenEnv)
+	}
+	return &PolarionUploader{
+		baseURL:    strings.TrimRight(baseURL, "/"),
+		token:      token,
+		httpClient: &http.Client{Timeout: 2 * time.Minute},
+	}, nil
+}
+
+// ProjectFromEnv returns the configured Polarion project ID.
+func ProjectFromEnv() string {
+	return os.Getenv(polarionProjectEnv)
+}
+
+// Upload creates a test run for the build and posts every labeled spec
+// result. defects maps RHACM4K case IDs to issue keys; a matched case is
+// annotated with its defect so the importer links them.
+func (u *PolarionUploader) Upload(ctx context.Context, report types.Report, opts PolarionOptions,
+	defects map[string]string) error {
+	document, err := buildPolarionDocument(report, opts)
+	if err != nil {
+		return err
+	}
+	linkDefects(document, defects)
+
+	encoded, err := xml.MarshalIndent(document, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode polarion upload: %w", err)
+	}
+
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	part, err := writer.CreateFormFile("file", "results.xml")
+	if err != nil {
+		return err
+	}
+	if _, err := part.Write(append([]byte(xml.Header), encoded...)); err != nil {
+		return err
+	}
+	if err := writer.Close(); err != nil {
+		return err
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, u.baseURL+"/import/xunit", &body)
+	if err != nil {
+		return fmt.Errorf("failed to build polarion upload request: %w", err)
+	}
+	request.Header.Set("Content-Type", writer.FormDataContentType())
+	request.Header.Set("Authorization", "Bearer "+u.token)
+
+	response, err := u.httpClient.Do(request)
+	if err != nil {
+		return fmt.Errorf("polarion upload failed: %w", err)
+	}
+	defer response.Body.Close()
+	responseBody, err := io.ReadAll(io.LimitReader(response.Body, 1<<20))
+	if err != nil {
+		return fmt.Errorf("failed to read polarion response: %w", err)
+	}
+	if response.StatusCode >= 300 {
+		return fmt.Errorf("polarion importer answered %d: %s", response.StatusCode, string(responseBody))
+	}
+	return nil
+}
+
+// linkDefects adds the defect work item reference to each failed case the
+// defect map covers.
+func linkDefects(document *polarionTestsuites, defects map[string]string) {
+	if len(defects) == 0 {
+		return
+	}
+	for i := range document.Suites {
+		for j := range document.Suites[i].Cases {
+			testcase := &document.Suites[i].Cases[j]
+			if testcase.Failure == nil {
+				continue
+			}
+			for _, property := range testcase.Properties.Properties {
+				if property.Name != "polarion-testcase-id" {
+					continue
+				}
+				if defect, ok := defects[property.Value]; ok {
+					testcase.Properties.Properties = append(testcase.Properties.Properties,
+						polarionProperty{Name: "polarion-testcase-comment", Value: "defect: " + defect})
+				}
+			}
+		}
+	}
+}
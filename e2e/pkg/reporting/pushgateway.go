@@ -0,0 +1,138 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package reporting
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/onsi/ginkgo/v2/types"
+
+	"github.com/stolostron/ai-test-gen/e2e/pkg/appliers"
+)
+
+// PushgatewayOptions configures the run-level metrics push.
+type PushgatewayOptions struct {
+	// URL is the Pushgateway base URL.
+	URL string
+	// Job is the Pushgateway job name, e.g. "acm-e2e".
+	Job string
+	// Instance distinguishes pipelines pushing under the same job,
+	// typically the hub name.
+	Instance string
+}
+
+// PushRunMetrics writes run-level metrics to a Prometheus Pushgateway in
+// text exposition format: pass/fail/skip counters, suite duration,
+// per-label durations, and the applier operation timings when a metrics
+// collector is supplied (nil is fine).
+func PushRunMetrics(ctx context.Context, opts PushgatewayOptions, report types.Report, applierMetrics *appliers.Metrics) error {
+	if opts.URL == "" || opts.Job == "" {
+		return fmt.Errorf("pushgateway URL and job are required")
+	}
+	body := renderRunMetrics(report, applierMetrics)
+
+	endpoint := fmt.Sprintf("%s/metrics/job/%s", strings.TrimRight(opts.URL, "/"), url.PathEscape(opts.Job))
+	if opts.Instance != "" {
+		endpoint += "/instance/" + url.PathEscape(opts.Instance)
+	}
+	request, err := http.NewRequestWithContext(ctx, http.MethodPut, endpoint, strings.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to build pushgateway request: %w", err)
+	}
+	request.Header.Set("Content-Type", "text/plain; version=0.0.4")
+
+	response, err := (&http.Client{Timeout: 30 * time.Second}).Do(request)
+	if err != nil {
+		return fmt.Errorf("failed to push run metrics: %w", err)
+	}
+	defer response.Body.Close()
+	if response.StatusCode >= 300 {
+		responseBody, _ := io.ReadAll(io.LimitReader(response.Body, 4096))
+		return fmt.Errorf("pushgateway answered %d: %s", response.StatusCode, string(responseBody))
+	}
+	return nil
+}
+
+func renderRunMetrics(report types.Report, applierMetrics *appliers.Metrics) string {
+	var passed, failed, skipped int
+	labelDurations := map[string]float64{}
+	for _, spec := range report.SpecReports {
+		if spec.LeafNodeType != types.NodeTypeIt {
+			continue
+		}
+		switch spec.State {
+		case types.SpecStatePassed:
+			passed++
+		case types.SpecStateSkipped, types.SpecStatePending:
+			skipped++
+		default:
+			failed++
+		}
+		for _, label := range spec.Labels() {
+			labelDurations[label] += spec.RunTime.Seconds()
+		}
+	}
+
+	var b strings.Builder
+	b.WriteString("# TYPE e2e_specs_total gauge\n")
+	fmt.Fprintf(&b, "e2e_specs_total{result=\"passed\"} %d\n", passed)
+	fmt.Fprintf(&b, "e2e_specs_total{result=\"failed\"} %d\n", failed)
+	fmt.Fprintf(&b, "e2e_specs_total{result=\"skipped\"} %d\n", skipped)
+	b.WriteString("# TYPE e2e_suite_duration_seconds gauge\n")
+	fmt.Fprintf(&b, "e2e_suite_duration_seconds %g\n", report.RunTime.Seconds())
+	b.WriteString("# TYPE e2e_suite_succeeded gauge\n")
+	fmt.Fprintf(&b, "e2e_suite_succeeded %d\n", boolToMetric(report.SuiteSucceeded))
+
+	if len(labelDurations) > 0 {
+		b.WriteString("# TYPE e2e_label_duration_seconds gauge\n")
+		for _, label := range sortedKeys(labelDurations) {
+			fmt.Fprintf(&b, "e2e_label_duration_seconds{label=%q} %g\n", label, labelDurations[label])
+		}
+	}
+
+	if applierMetrics != nil {
+		snapshot := applierMetrics.Snapshot()
+		if len(snapshot) > 0 {
+			b.WriteString("# TYPE e2e_applier_operations_total gauge\n")
+			b.WriteString("# TYPE e2e_applier_operation_errors_total gauge\n")
+			b.WriteString("# TYPE e2e_applier_operation_max_latency_seconds gauge\n")
+			keys := make([]string, 0, len(snapshot))
+			for key := range snapshot {
+				keys = append(keys, key)
+			}
+			sort.Strings(keys)
+			for _, key := range keys {
+				stats := snapshot[key]
+				verb, kind, _ := strings.Cut(key, " ")
+				labels := fmt.Sprintf("{verb=%q,kind=%q}", verb, kind)
+				fmt.Fprintf(&b, "e2e_applier_operations_total%s %d\n", labels, stats.Count)
+				fmt.Fprintf(&b, "e2e_applier_operation_errors_total%s %d\n", labels, stats.Errors)
+				fmt.Fprintf(&b, "e2e_applier_operation_max_latency_seconds%s %g\n", labels, stats.MaxLatency.Seconds())
+			}
+		}
+	}
+	return b.String()
+}
+
+func boolToMetric(value bool) int {
+	if value {
+		return 1
+	}
+	return 0
+}
+
+func sortedKeys(m map[string]float64) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,247 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package reporting
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/onsi/ginkgo/v2/types"
+)
+
+// ReportPortal environment variables, read alongside JUNIT_REPORT_FILE in
+// the suite bootstrap.
+const (
+	rpEndpointEnv = "RP_ENDPOINT"
+	rpTokenEnv    = "RP_TOKEN"
+	rpProjectEnv  = "RP_PROJECT"
+	rpLaunchEnv   = "RP_LAUNCH"
+)
+
+// ReportPortalClient streams a suite run to a ReportPortal server as it
+// executes: one launch per suite, one test item per spec, with failure
+// output attached as logs.
+type ReportPortalClient struct {
+	endpoint string
+	token    string
+	project  string
+	launch   string
+
+	httpClient *http.Client
+
+	mu       sync.Mutex
+	launchID string
+	items    map[string]string // spec full text -> test item ID
+}
+
+// NewReportPortalFromEnv builds a client from RP_* environment variables.
+// It returns nil when RP_ENDPOINT is unset, so callers can register the
+// integration unconditionally.
+func NewReportPortalFromEnv() (*ReportPortalClient, error) {
+	endpoint := os.Getenv(rpEndpointEnv)
+	if endpoint == "" {
+		return nil, nil
+	}
+	token := os.Getenv(rpTokenEnv)
+	project := os.Getenv(rpProjectEnv)
+	if token == "" || project == "" {
+		return nil, fmt.Errorf("%s is set but %s or %s is missing", rpEndpointEnv, rpTokenEnv, rpProjectEnv)
+	}
+	launch := os.Getenv(rpLaunchEnv)
+	if launch == "" {
+		launch = "acm-e2e"
+	}
+	return &ReportPortalClient{
+		endpoint:   endpoint,
+		token:      token,
+		project:    project,
+		launch:     launch,
+		httpClient: &http.Client{Timeout: 30 * time.Second},
+		items:      map[string]string{},
+	}, nil
+}
+
+// StartLaunch opens the launch; call it from BeforeSuite.
+func (c *ReportPortalClient) StartLaunch(ctx context.Context, description string) error {
+	response := struct {
+		ID string `json:"id"`
+	}{}
+	err := c.post(ctx, "launch", map[string]interface{}{
+		"name":        c.launch,
+		"description": description,
+		"startTime":   rpTime(time.Now()),
+		"mode":        "DEFAULT",
+	}, &response)
+	if err != nil {
+		return fmt.Errorf("failed to start reportportal launch: %w", err)
+	}
+	c.mu.Lock()
+	c.launchID = response.ID
+	c.mu.Unlock()
+	return nil
+}
+
+// BeginSpec opens a test item for the spec; call it from a
+// ReportBeforeEach node.
+func (c *ReportPortalClient) BeginSpec(ctx context.Context, spec types.SpecReport) error {
+	c.mu.Lock()
+	launchID := c.launchID
+	c.mu.Unlock()
+	if launchID == "" {
+		return fmt.Errorf("reportportal launch is not started")
+	}
+	response := struct {
+		ID string `json:"id"`
+	}{}
+	err := c.post(ctx, "item", map[string]interface{}{
+		"name":       truncateName(spec.FullText(), 256),
+		"type":       "STEP",
+		"launchUuid": launchID,
+		"startTime":  rpTime(time.Now()),
+		"attributes": rpAttributes(spec.Labels()),
+	}, &response)
+	if err != nil {
+		return fmt.Errorf("failed to start reportportal item: %w", err)
+	}
+	c.mu.Lock()
+	c.items[spec.FullText()] = response.ID
+	c.mu.Unlock()
+	return nil
+}
+
+// EndSpec closes the spec's test item with its result and attaches the
+// failure message and captured output as logs; call it from a
+// ReportAfterEach node.
+func (c *ReportPortalClient) EndSpec(ctx context.Context, spec types.SpecReport) error {
+	c.mu.Lock()
+	itemID := c.items[spec.FullText()]
+	launchID := c.launchID
+	delete(c.items, spec.FullText())
+	c.mu.Unlock()
+	if itemID == "" {
+		return fmt.Errorf("reportportal item for %q was never started", spec.FullText())
+	}
+
+	status := "PASSED"
+	switch spec.State {
+	case types.SpecStatePassed:
+	case types.SpecStateSkipped, types.SpecStatePending:
+		status = "SKIPPED"
+	default:
+		status = "FAILED"
+		if err := c.log(ctx, launchID, itemID, "ERROR", formatSpecFailure(spec)); err != nil {
+			return err
+		}
+		if output := spec.CapturedGinkgoWriterOutput; output != "" {
+			if err := c.log(ctx, launchID, itemID, "INFO", output); err != nil {
+				return err
+			}
+		}
+	}
+	err := c.put(ctx, "item/"+itemID, map[string]interface{}{
+		"launchUuid": launchID,
+		"endTime":    rpTime(time.Now()),
+		"status":     status,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to finish reportportal item: %w", err)
+	}
+	return nil
+}
+
+// FinishLaunch closes the launch; call it from ReportAfterSuite.
+func (c *ReportPortalClient) FinishLaunch(ctx context.Context) error {
+	c.mu.Lock()
+	launchID := c.launchID
+	c.mu.Unlock()
+	if launchID == "" {
+		return nil
+	}
+	err := c.put(ctx, "launch/"+launchID+"/finish", map[string]interface{}{
+		"endTime": rpTime(time.Now()),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to finish reportportal launch: %w", err)
+	}
+	return nil
+}
+
+func (c *ReportPortalClient) log(ctx context.Context, launchID, itemID, level, message string) error {
+	err := c.post(ctx, "log", map[string]interface{}{
+		"launchUuid": launchID,
+		"itemUuid":   itemID,
+		"time":       rpTime(time.Now()),
+		"level":      level,
+		"message":    message,
+	}, nil)
+	if err != nil {
+		return fmt.Errorf("failed to send reportportal log: %w", err)
+	}
+	return nil
+}
+
+func (c *ReportPortalClient) post(ctx context.Context, path string, body interface{}, out interface{}) error {
+	return c.do(ctx, http.MethodPost, path, body, out)
+}
+
+func (c *ReportPortalClient) put(ctx context.Context, path string, body interface{}) error {
+	return c.do(ctx, http.MethodPut, path, body, nil)
+}
+
+func (c *ReportPortalClient) do(ctx context.Context, method, path string, body interface{}, out interface{}) error {
+	encoded, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+	url := fmt.Sprintf("%s/api/v1/%s/%s", c.endpoint, c.project, path)
+	request, err := http.NewRequestWithContext(ctx, method, url, bytes.NewReader(encoded))
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("Authorization", "Bearer "+c.token)
+
+	response, err := c.httpClient.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+	responseBody, err := io.ReadAll(io.LimitReader(response.Body, 1<<20))
+	if err != nil {
+		return err
+	}
+	if response.StatusCode >= 300 {
+		return fmt.Errorf("%s %s answered %d: %s", method, url, response.StatusCode, string(responseBody))
+	}
+	if out != nil {
+		return json.Unmarshal(responseBody, out)
+	}
+	return nil
+}
+
+// rpTime renders timestamps in the ISO form ReportPortal expects.
+func rpTime(t time.Time) string {
+	return t.UTC().Format("2006-01-02T15:04:05.000Z")
+}
+
+func rpAttributes(labels []string) []map[string]string {
+	attributes := make([]map[string]string, 0, len(labels))
+	for _, label := range labels {
+		attributes = append(attributes, map[string]string{"value": label})
+	}
+	return attributes
+}
+
+func truncateName(name string, limit int) string {
+	if len(name) <= limit {
+		return name
+	}
+	return name[:limit-3] + "..."
+}
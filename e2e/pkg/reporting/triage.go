@@ -0,0 +1,221 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package reporting
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/onsi/ginkgo/v2/types"
+)
+
+// TriageClass is the verdict of failure classification.
+type TriageClass string
+
+const (
+	TriageProductBug  TriageClass = "product-bug"
+	TriageTestBug     TriageClass = "test-bug"
+	TriageEnvironment TriageClass = "environment"
+	TriageUnknown     TriageClass = "unknown"
+)
+
+// Failure is the evidence handed to the classifier for one failed spec.
+type Failure struct {
+	SpecName string
+	CaseID   string
+	Message  string
+	// Logs is the spec's captured output, truncated by the caller.
+	Logs string
+	// Events is the formatted event dump the diagnostics hooks attach on
+	// failure, when available.
+	Events string
+}
+
+// Triage is one classified failure.
+type Triage struct {
+	SpecName  string
+	Class     TriageClass
+	Rationale string
+}
+
+// Classifier turns failure evidence into a triage verdict. The production
+// implementation delegates to the AI layer; tests can plug in a stub.
+type Classifier interface {
+	Classify(ctx context.Context, failure Failure) (Triage, error)
+}
+
+// AI triage environment variables.
+const (
+	aiTriageEndpointEnv = "AI_TRIAGE_ENDPOINT"
+	aiTriageTokenEnv    = "AI_TRIAGE_TOKEN"
+)
+
+// AIClassifier classifies failures through the AI layer's completion
+// endpoint.
+type AIClassifier struct {
+	endpoint   string
+	token      string
+	httpClient *http.Client
+}
+
+// NewAIClassifierFromEnv returns a classifier configured from
+// AI_TRIAGE_* variables, or nil when the endpoint is unset.
+func NewAIClassifierFromEnv() *AIClassifier {
+	endpoint := os.Getenv(aiTriageEndpointEnv)
+	if endpoint == "" {
+		return nil
+	}
+	return &AIClassifier{
+		endpoint:   endpoint,
+		token:      os.Getenv(aiTriageTokenEnv),
+		httpClient: &http.Client{Timeout: 60 * time.Second},
+	}
+}
+
+// triagePrompt constrains the answer to a parseable verdict line followed
+// by a short rationale.
+const triagePrompt = `Classify this end-to-end test failure as exactly one of: product-bug, test-bug, environment.
+Answer with the class on the first line and a one-sentence rationale on the second line.
+
+Spec: %s
+Failure message:
+%s
+
+Captured logs (truncated):
+%s
+
+Cluster events (truncated):
+%s`
+
+func (c *AIClassifier) Classify(ctx context.Context, failure Failure) (Triage, error) {
+	prompt := fmt.Sprintf(triagePrompt,
+		failure.SpecName,
+		truncateEvidence(failure.Message, 4000),
+		truncateEvidence(failure.Logs, 8000),
+		truncateEvidence(failure.Events, 4000))
+	payload, err := json.Marshal(map[string]string{"prompt": prompt})
+	if err != nil {
+		return Triage{}, err
+	}
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, c.endpoint, bytes.NewReader(payload))
+	if err != nil {
+		return Triage{}, fmt.Errorf("failed to build triage request: %w", err)
+	}
+	request.Header.Set("Content-Type", "application/json")
+	if c.token != "" {
+		request.Header.Set("Authorization", "Bearer "+c.token)
+	}
+
+	response, err := c.httpClient.Do(request)
+	if err != nil {
+		return Triage{}, fmt.Errorf("triage request failed: %w", err)
+	}
+	defer response.Body.Close()
+	body, err := io.ReadAll(io.LimitReader(response.Body, 1<<20))
+	if err != nil {
+		return Triage{}, fmt.Errorf("failed to read triage response: %w", err)
+	}
+	if response.StatusCode != http.StatusOK {
+		return Triage{}, fmt.Errorf("triage endpoint answered %d: %s", response.StatusCode, string(body))
+	}
+	var parsed struct {
+		Completion string `json:"completion"`
+	}
+	if err := json.Unmarshal(body, &parsed); err != nil {
+		return Triage{}, fmt.Errorf("failed to parse triage response: %w", err)
+	}
+	return parseTriageAnswer(failure.SpecName, parsed.Completion), nil
+}
+
+// parseTriageAnswer tolerates extra prose around the verdict; an answer
+// that names no known class is recorded as unknown rather than dropped.
+func parseTriageAnswer(specName, answer string) Triage {
+	triage := Triage{SpecName: specName, Class: TriageUnknown}
+	lines := strings.SplitN(strings.TrimSpace(answer), "\n", 2)
+	verdict := strings.ToLower(strings.TrimSpace(lines[0]))
+	for _, class := range []TriageClass{TriageProductBug, TriageTestBug, TriageEnvironment} {
+		if strings.Contains(verdict, string(class)) {
+			triage.Class = class
+			break
+		}
+	}
+	if len(lines) > 1 {
+		triage.Rationale = strings.TrimSpace(lines[1])
+	}
+	return triage
+}
+
+// TriageFailures classifies every failed spec of the report. Classifier
+// errors degrade that failure to unknown with the error as rationale —
+// triage must never turn a red run into a crashed reporter.
+func TriageFailures(ctx context.Context, classifier Classifier, report types.Report) []Triage {
+	var triages []Triage
+	for _, spec := range report.SpecReports {
+		if spec.LeafNodeType != types.NodeTypeIt {
+			continue
+		}
+		if spec.State == types.SpecStatePassed || spec.State == types.SpecStateSkipped ||
+			spec.State == types.SpecStatePending {
+			continue
+		}
+		failure := Failure{
+			SpecName: spec.FullText(),
+			CaseID:   polarionCaseID(spec),
+			Message:  spec.Failure.Message,
+			Logs:     spec.CapturedGinkgoWriterOutput,
+			Events:   reportEntryValue(spec, "events"),
+		}
+		triage, err := classifier.Classify(ctx, failure)
+		if err != nil {
+			triage = Triage{
+				SpecName:  failure.SpecName,
+				Class:     TriageUnknown,
+				Rationale: fmt.Sprintf("classification failed: %v", err),
+			}
+		}
+		triages = append(triages, triage)
+	}
+	return triages
+}
+
+// AnnotateJUnitWithTriage appends each verdict to the matching JUnit
+// failure text so the classification travels with the report.
+func AnnotateJUnitWithTriage(suites *JUnitTestSuites, triages []Triage) {
+	byName := map[string]Triage{}
+	for _, triage := range triages {
+		byName[triage.SpecName] = triage
+	}
+	for i := range suites.Suites {
+		for j := range suites.Suites[i].Cases {
+			testcase := &suites.Suites[i].Cases[j]
+			triage, ok := byName[testcase.Name]
+			if !ok || testcase.Failure == nil {
+				continue
+			}
+			testcase.Failure.Body += fmt.Sprintf("\n[triage: %s — %s]", triage.Class, triage.Rationale)
+		}
+	}
+}
+
+func reportEntryValue(spec types.SpecReport, name string) string {
+	for _, entry := range spec.ReportEntries {
+		if entry.Name == name {
+			return entry.Value.String()
+		}
+	}
+	return ""
+}
+
+func truncateEvidence(text string, limit int) string {
+	if len(text) <= limit {
+		return text
+	}
+	return text[:limit] + "\n[truncated]"
+}
@@ -0,0 +1,158 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package reporting
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/onsi/ginkgo/v2/types"
+)
+
+func TestParseTriageAnswer(t *testing.T) {
+	cases := []struct {
+		name      string
+		answer    string
+		class     TriageClass
+		rationale string
+	}{
+		{
+			name:      "clean verdict",
+			answer:    "product-bug\nThe API server rejected a valid spec.",
+			class:     TriageProductBug,
+			rationale: "The API server rejected a valid spec.",
+		},
+		{
+			name:      "verdict with prose around it",
+			answer:    "  The class is: test-bug\nThe selector in the spec is wrong.",
+			class:     TriageTestBug,
+			rationale: "The selector in the spec is wrong.",
+		},
+		{
+			name:   "environment, no rationale",
+			answer: "ENVIRONMENT",
+			class:  TriageEnvironment,
+		},
+		{
+			name:      "unknown verdict",
+			answer:    "cannot tell\nNot enough evidence.",
+			class:     TriageUnknown,
+			rationale: "Not enough evidence.",
+		},
+		{
+			name:   "empty answer",
+			answer: "",
+			class:  TriageUnknown,
+		},
+		{
+			name:      "multi-line rationale kept intact",
+			answer:    "product-bug\nline one\nline two",
+			class:     TriageProductBug,
+			rationale: "line one\nline two",
+		},
+	}
+	for _, testcase := range cases {
+		t.Run(testcase.name, func(t *testing.T) {
+			triage := parseTriageAnswer("spec", testcase.answer)
+			if triage.Class != testcase.class {
+				t.Errorf("class = %s, want %s", triage.Class, testcase.class)
+			}
+			if triage.Rationale != testcase.rationale {
+				t.Errorf("rationale = %q, want %q", triage.Rationale, testcase.rationale)
+			}
+			if triage.SpecName != "spec" {
+				t.Errorf("spec name = %q", triage.SpecName)
+			}
+		})
+	}
+}
+
+// stubClassifier is the test seam advertised on the Classifier interface.
+type stubClassifier struct {
+	triage   Triage
+	err      error
+	failures []Failure
+}
+
+func (s *stubClassifier) Classify(ctx context.Context, failure Failure) (Triage, error) {
+	s.failures = append(s.failures, failure)
+	triage := s.triage
+	triage.SpecName = failure.SpecName
+	return triage, s.err
+}
+
+func triageTestReport() types.Report {
+	return types.Report{SpecReports: types.SpecReports{
+		{
+			LeafNodeType:            types.NodeTypeIt,
+			State:                   types.SpecStateFailed,
+			ContainerHierarchyTexts: []string{"Import"},
+			LeafNodeText:            "imports the cluster",
+			LeafNodeLabels:          []string{"RHACM4K-2"},
+			Failure:                 types.Failure{Message: "timed out waiting for Available"},
+		},
+		{
+			LeafNodeType: types.NodeTypeIt,
+			State:        types.SpecStatePassed,
+			LeafNodeText: "provisions the cluster",
+		},
+		{
+			LeafNodeType: types.NodeTypeBeforeSuite,
+			State:        types.SpecStateFailed,
+		},
+	}}
+}
+
+func TestTriageFailuresClassifiesOnlyFailedLeaves(t *testing.T) {
+	classifier := &stubClassifier{triage: Triage{Class: TriageEnvironment, Rationale: "hub unreachable"}}
+	triages := TriageFailures(context.Background(), classifier, triageTestReport())
+
+	if len(triages) != 1 {
+		t.Fatalf("got %d triages, want 1 (failed leaf specs only)", len(triages))
+	}
+	if triages[0].Class != TriageEnvironment || triages[0].SpecName != "Import imports the cluster" {
+		t.Errorf("triage = %+v", triages[0])
+	}
+	if len(classifier.failures) != 1 || classifier.failures[0].CaseID != "RHACM4K-2" {
+		t.Errorf("classifier evidence = %+v, want the RHACM4K-2 failure", classifier.failures)
+	}
+}
+
+func TestTriageFailuresDegradesClassifierErrors(t *testing.T) {
+	classifier := &stubClassifier{err: errors.New("endpoint down")}
+	triages := TriageFailures(context.Background(), classifier, triageTestReport())
+
+	if len(triages) != 1 {
+		t.Fatalf("got %d triages, want 1", len(triages))
+	}
+	if triages[0].Class != TriageUnknown {
+		t.Errorf("class = %s, want unknown when the classifier errors", triages[0].Class)
+	}
+	if !strings.Contains(triages[0].Rationale, "endpoint down") {
+		t.Errorf("rationale %q does not carry the classifier error", triages[0].Rationale)
+	}
+}
+
+func TestAnnotateJUnitWithTriage(t *testing.T) {
+	suites := &JUnitTestSuites{Suites: []JUnitTestSuite{{
+		Cases: []JUnitTestCase{
+			{Name: "Import imports the cluster", Failure: &JUnitMessage{Body: "timed out"}},
+			{Name: "provisions the cluster"},
+		},
+	}}}
+	AnnotateJUnitWithTriage(suites, []Triage{{
+		SpecName:  "Import imports the cluster",
+		Class:     TriageProductBug,
+		Rationale: "registration webhook rejects valid CSR",
+	}})
+
+	body := suites.Suites[0].Cases[0].Failure.Body
+	if !strings.Contains(body, "product-bug") || !strings.Contains(body, "registration webhook") {
+		t.Errorf("failure body %q missing triage annotation", body)
+	}
+	if suites.Suites[0].Cases[1].Failure != nil {
+		t.Error("passing case was annotated")
+	}
+}
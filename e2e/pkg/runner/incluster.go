@@ -0,0 +1,195 @@
+// Copyright Contributors to the Open Cluster Management project
+
+// Package runner executes the suites as workloads on the hub itself, for
+// labs where the hub API is not reachable from outside the cluster.
+package runner
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	runnerPollInterval = 15 * time.Second
+	// runnerNamespace hosts the runner Job and its service account.
+	runnerNamespace = "acm-e2e-runner"
+	runnerJobName   = "acm-e2e"
+)
+
+// JobOptions configures an in-cluster suite run.
+type JobOptions struct {
+	// Image is the suite container image.
+	Image string
+	// Args are passed to the suite entrypoint (label filters, focus).
+	Args []string
+	// Env is extra environment for the suite process; the in-cluster
+	// service account token provides hub access, so no kubeconfig is
+	// mounted.
+	Env map[string]string
+	// Timeout bounds the Job via activeDeadlineSeconds. Defaults to 4h.
+	Timeout time.Duration
+	// ArtifactsPVC, when set, is mounted at /artifacts so reports
+	// survive the pod.
+	ArtifactsPVC string
+}
+
+// CreateRunnerJob provisions the namespace, service account and
+// cluster-admin binding the suites need, then creates the Job. The
+// suites authenticate through the mounted service account token, which
+// is what makes restricted environments work.
+func CreateRunnerJob(ctx context.Context, hub client.Client, opts JobOptions) (*batchv1.Job, error) {
+	if opts.Image == "" {
+		return nil, fmt.Errorf("runner image is required")
+	}
+	timeout := opts.Timeout
+	if timeout <= 0 {
+		timeout = 4 * time.Hour
+	}
+
+	namespace := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: runnerNamespace}}
+	if err := hub.Create(ctx, namespace); err != nil && !apierrors.IsAlreadyExists(err) {
+		return nil, fmt.Errorf("failed to create runner namespace: %w", err)
+	}
+	serviceAccount := &corev1.ServiceAccount{
+		ObjectMeta: metav1.ObjectMeta{Name: runnerJobName, Namespace: runnerNamespace},
+	}
+	if err := hub.Create(ctx, serviceAccount); err != nil && !apierrors.IsAlreadyExists(err) {
+		return nil, fmt.Errorf("failed to create runner service account: %w", err)
+	}
+	// Lifecycle suites create cluster-scoped resources (ManagedClusters,
+	// ClusterImageSets); nothing short of cluster-admin covers them.
+	binding := &rbacv1.ClusterRoleBinding{
+		ObjectMeta: metav1.ObjectMeta{Name: runnerJobName + "-admin"},
+		RoleRef: rbacv1.RoleRef{
+			APIGroup: rbacv1.GroupName, Kind: "ClusterRole", Name: "cluster-admin",
+		},
+		Subjects: []rbacv1.Subject{{
+			Kind: rbacv1.ServiceAccountKind, Name: runnerJobName, Namespace: runnerNamespace,
+		}},
+	}
+	if err := hub.Create(ctx, binding); err != nil && !apierrors.IsAlreadyExists(err) {
+		return nil, fmt.Errorf("failed to create runner role binding: %w", err)
+	}
+
+	job := newRunnerJob(opts, timeout)
+	if err := hub.Create(ctx, job); err != nil {
+		return nil, fmt.Errorf("failed to create runner job: %w", err)
+	}
+	return job, nil
+}
+
+func newRunnerJob(opts JobOptions, timeout time.Duration) *batchv1.Job {
+	var env []corev1.EnvVar
+	for name, value := range opts.Env {
+		env = append(env, corev1.EnvVar{Name: name, Value: value})
+	}
+	container := corev1.Container{
+		Name:  "e2e",
+		Image: opts.Image,
+		Args:  opts.Args,
+		Env:   env,
+	}
+	podSpec := corev1.PodSpec{
+		ServiceAccountName: runnerJobName,
+		RestartPolicy:      corev1.RestartPolicyNever,
+		Containers:         []corev1.Container{container},
+	}
+	if opts.ArtifactsPVC != "" {
+		podSpec.Volumes = []corev1.Volume{{
+			Name: "artifacts",
+			VolumeSource: corev1.VolumeSource{
+				PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: opts.ArtifactsPVC},
+			},
+		}}
+		podSpec.Containers[0].VolumeMounts = []corev1.VolumeMount{{Name: "artifacts", MountPath: "/artifacts"}}
+		podSpec.Containers[0].Env = append(podSpec.Containers[0].Env,
+			corev1.EnvVar{Name: "ARTIFACT_DIR", Value: "/artifacts"})
+	}
+	return &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{Name: runnerJobName, Namespace: runnerNamespace},
+		Spec: batchv1.JobSpec{
+			BackoffLimit:          ptr.To(int32(0)),
+			ActiveDeadlineSeconds: ptr.To(int64(timeout.Seconds())),
+			Template:              corev1.PodTemplateSpec{Spec: podSpec},
+		},
+	}
+}
+
+// WaitForRunnerJob waits for the Job to finish and reports its verdict.
+func WaitForRunnerJob(ctx context.Context, hub client.Client, timeout time.Duration) error {
+	job := &batchv1.Job{}
+	err := wait.PollUntilContextTimeout(ctx, runnerPollInterval, timeout, true,
+		func(ctx context.Context) (bool, error) {
+			if err := hub.Get(ctx, client.ObjectKey{Namespace: runnerNamespace, Name: runnerJobName}, job); err != nil {
+				return false, err
+			}
+			for _, condition := range job.Status.Conditions {
+				if condition.Status != corev1.ConditionTrue {
+					continue
+				}
+				if condition.Type == batchv1.JobComplete {
+					return true, nil
+				}
+				if condition.Type == batchv1.JobFailed {
+					return false, fmt.Errorf("runner job failed: %s", condition.Message)
+				}
+			}
+			return false, nil
+		})
+	if err != nil {
+		return fmt.Errorf("runner job did not complete: %w", err)
+	}
+	return nil
+}
+
+// StreamRunnerLogs copies the runner pod's log to the writer, following
+// it while the suite runs.
+func StreamRunnerLogs(ctx context.Context, hubClientset kubernetes.Interface, out io.Writer) error {
+	pods, err := hubClientset.CoreV1().Pods(runnerNamespace).List(ctx, metav1.ListOptions{
+		LabelSelector: "job-name=" + runnerJobName,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to list runner pods: %w", err)
+	}
+	if len(pods.Items) == 0 {
+		return fmt.Errorf("no runner pods found")
+	}
+	pod := pods.Items[len(pods.Items)-1]
+	stream, err := hubClientset.CoreV1().Pods(runnerNamespace).
+		GetLogs(pod.Name, &corev1.PodLogOptions{Follow: true}).Stream(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to stream runner logs: %w", err)
+	}
+	defer stream.Close()
+	if _, err := io.Copy(out, stream); err != nil {
+		return fmt.Errorf("failed to copy runner logs: %w", err)
+	}
+	return nil
+}
+
+// DeleteRunnerJob removes the Job and its RBAC, leaving the namespace
+// for artifact retrieval.
+func DeleteRunnerJob(ctx context.Context, hub client.Client) error {
+	job := &batchv1.Job{ObjectMeta: metav1.ObjectMeta{Name: runnerJobName, Namespace: runnerNamespace}}
+	policy := metav1.DeletePropagationForeground
+	if err := hub.Delete(ctx, job, &client.DeleteOptions{PropagationPolicy: &policy}); err != nil && !apierrors.IsNotFound(err) {
+		return fmt.Errorf("failed to delete runner job: %w", err)
+	}
+	binding := &rbacv1.ClusterRoleBinding{ObjectMeta: metav1.ObjectMeta{Name: runnerJobName + "-admin"}}
+	if err := hub.Delete(ctx, binding); err != nil && !apierrors.IsNotFound(err) {
+		return fmt.Errorf("failed to delete runner role binding: %w", err)
+	}
+	return nil
+}
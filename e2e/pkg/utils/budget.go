@@ -0,0 +1,63 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package utils
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/onsi/ginkgo/v2"
+)
+
+// SpecBudget enforces a per-spec time budget through context
+// cancellation. Helpers that take a context abort cleanly when the budget
+// expires, so one wedged operation fails its own spec with diagnostics
+// instead of stalling the suite until the ginkgo global timeout.
+type SpecBudget struct {
+	// Timeout is the budget for the spec. Zero means no budget.
+	Timeout time.Duration
+	// OnTimeout, when set, is invoked once if the budget expires, with a
+	// short grace context for collecting diagnostics.
+	OnTimeout func(ctx context.Context)
+	// DiagnosticsGrace bounds OnTimeout. Defaults to 30s.
+	DiagnosticsGrace time.Duration
+}
+
+// Context derives a spec-scoped context from parent. The cancellation is
+// registered with ginkgo.DeferCleanup so the budget ends with the spec
+// either way, and expiry triggers OnTimeout exactly once before the
+// helpers observe the cancellation.
+func (b SpecBudget) Context(parent context.Context) context.Context {
+	if b.Timeout <= 0 {
+		return parent
+	}
+	ctx, cancel := context.WithTimeoutCause(parent, b.Timeout,
+		fmt.Errorf("spec budget of %s exhausted", b.Timeout))
+	ginkgo.DeferCleanup(cancel)
+
+	if b.OnTimeout != nil {
+		grace := b.DiagnosticsGrace
+		if grace <= 0 {
+			grace = 30 * time.Second
+		}
+		stop := context.AfterFunc(ctx, func() {
+			// Only a deadline expiry warrants diagnostics; normal spec
+			// completion cancels the context too.
+			if context.Cause(ctx) == context.Canceled {
+				return
+			}
+			diagCtx, diagCancel := context.WithTimeout(context.WithoutCancel(ctx), grace)
+			defer diagCancel()
+			b.OnTimeout(diagCtx)
+		})
+		ginkgo.DeferCleanup(stop)
+	}
+	return ctx
+}
+
+// WithSpecBudget is the common-case shorthand: a budgeted context with no
+// timeout diagnostics.
+func WithSpecBudget(parent context.Context, timeout time.Duration) context.Context {
+	return SpecBudget{Timeout: timeout}.Context(parent)
+}
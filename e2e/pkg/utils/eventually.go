@@ -0,0 +1,95 @@
+// Copyright Contributors to the Open Cluster Management project
+
+// Package utils holds small framework-wide helpers that do not belong to a
+// specific lifecycle area.
+package utils
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"time"
+)
+
+// BackoffOptions tunes Eventually's retry schedule.
+type BackoffOptions struct {
+	// Initial is the first retry interval. Defaults to 1s.
+	Initial time.Duration
+	// Max caps the interval growth. Defaults to 30s.
+	Max time.Duration
+	// Factor multiplies the interval after each attempt. Defaults to 2.
+	Factor float64
+	// Jitter adds up to this fraction of the interval as random delay,
+	// de-synchronizing parallel pollers. Defaults to 0.2.
+	Jitter float64
+	// Timeout bounds the total wait. Defaults to 5m.
+	Timeout time.Duration
+}
+
+func (o BackoffOptions) withDefaults() BackoffOptions {
+	if o.Initial <= 0 {
+		o.Initial = time.Second
+	}
+	if o.Max <= 0 {
+		o.Max = 30 * time.Second
+	}
+	if o.Factor <= 1 {
+		o.Factor = 2
+	}
+	if o.Jitter < 0 {
+		o.Jitter = 0
+	} else if o.Jitter == 0 {
+		o.Jitter = 0.2
+	}
+	if o.Timeout <= 0 {
+		o.Timeout = 5 * time.Minute
+	}
+	return o
+}
+
+// Eventually retries condition with exponential backoff and jitter until it
+// returns true, the timeout expires or the context is cancelled. Unlike a
+// fixed-interval poll, the growing interval keeps fast conditions fast
+// without hammering the API server during long waits.
+//
+// A non-nil error from condition aborts the wait immediately; transient
+// errors should be swallowed by the condition and reported as "not done
+// yet". On timeout the error wraps the last condition error state through
+// the message of the final attempt.
+func Eventually(ctx context.Context, opts BackoffOptions, condition func(ctx context.Context) (bool, error)) error {
+	opts = opts.withDefaults()
+	ctx, cancel := context.WithTimeout(ctx, opts.Timeout)
+	defer cancel()
+
+	interval := opts.Initial
+	attempt := 0
+	for {
+		attempt++
+		done, err := condition(ctx)
+		if err != nil {
+			return fmt.Errorf("condition failed permanently on attempt %d: %w", attempt, err)
+		}
+		if done {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("condition not met after %d attempts within %s: %w", attempt, opts.Timeout, ctx.Err())
+		case <-time.After(jittered(interval, opts.Jitter)):
+		}
+
+		interval = time.Duration(float64(interval) * opts.Factor)
+		if interval > opts.Max {
+			interval = opts.Max
+		}
+	}
+}
+
+// jittered adds up to fraction of the interval as random delay.
+func jittered(interval time.Duration, fraction float64) time.Duration {
+	if fraction <= 0 {
+		return interval
+	}
+	return interval + time.Duration(rand.Float64()*fraction*float64(interval))
+}
@@ -0,0 +1,104 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package utils
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestBackoffOptionsDefaults(t *testing.T) {
+	defaults := BackoffOptions{}.withDefaults()
+	if defaults.Initial != time.Second {
+		t.Errorf("Initial defaulted to %s, want 1s", defaults.Initial)
+	}
+	if defaults.Max != 30*time.Second {
+		t.Errorf("Max defaulted to %s, want 30s", defaults.Max)
+	}
+	if defaults.Factor != 2 {
+		t.Errorf("Factor defaulted to %v, want 2", defaults.Factor)
+	}
+	if defaults.Jitter != 0.2 {
+		t.Errorf("Jitter defaulted to %v, want 0.2", defaults.Jitter)
+	}
+	if defaults.Timeout != 5*time.Minute {
+		t.Errorf("Timeout defaulted to %s, want 5m", defaults.Timeout)
+	}
+
+	// Explicit values survive, and a negative jitter means none at all
+	// rather than the default.
+	tuned := BackoffOptions{Initial: 5 * time.Millisecond, Jitter: -1}.withDefaults()
+	if tuned.Initial != 5*time.Millisecond {
+		t.Errorf("explicit Initial overridden to %s", tuned.Initial)
+	}
+	if tuned.Jitter != 0 {
+		t.Errorf("negative Jitter normalized to %v, want 0", tuned.Jitter)
+	}
+}
+
+func TestJittered(t *testing.T) {
+	interval := 100 * time.Millisecond
+	if got := jittered(interval, 0); got != interval {
+		t.Errorf("jittered with zero fraction = %s, want %s", got, interval)
+	}
+	for i := 0; i < 100; i++ {
+		got := jittered(interval, 0.5)
+		if got < interval || got > interval+interval/2 {
+			t.Fatalf("jittered(%s, 0.5) = %s, want within [%s, %s]",
+				interval, got, interval, interval+interval/2)
+		}
+	}
+}
+
+func TestEventuallySucceedsAfterRetries(t *testing.T) {
+	attempts := 0
+	err := Eventually(context.Background(),
+		BackoffOptions{Initial: time.Millisecond, Max: 5 * time.Millisecond, Timeout: time.Second},
+		func(ctx context.Context) (bool, error) {
+			attempts++
+			return attempts >= 3, nil
+		})
+	if err != nil {
+		t.Fatalf("Eventually returned %v, want success", err)
+	}
+	if attempts != 3 {
+		t.Errorf("condition ran %d times, want 3", attempts)
+	}
+}
+
+func TestEventuallyConditionErrorAborts(t *testing.T) {
+	permanent := errors.New("boom")
+	attempts := 0
+	err := Eventually(context.Background(),
+		BackoffOptions{Initial: time.Millisecond, Timeout: time.Second},
+		func(ctx context.Context) (bool, error) {
+			attempts++
+			return false, permanent
+		})
+	if !errors.Is(err, permanent) {
+		t.Fatalf("Eventually returned %v, want wrapped %v", err, permanent)
+	}
+	if attempts != 1 {
+		t.Errorf("condition ran %d times after a permanent error, want 1", attempts)
+	}
+}
+
+func TestEventuallyTimesOut(t *testing.T) {
+	err := Eventually(context.Background(),
+		BackoffOptions{Initial: time.Millisecond, Max: 2 * time.Millisecond, Timeout: 30 * time.Millisecond},
+		func(ctx context.Context) (bool, error) {
+			return false, nil
+		})
+	if err == nil {
+		t.Fatal("Eventually returned nil, want timeout error")
+	}
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("timeout error = %v, want context.DeadlineExceeded in chain", err)
+	}
+	if !strings.Contains(err.Error(), "attempts") {
+		t.Errorf("timeout error %q does not mention the attempt count", err)
+	}
+}
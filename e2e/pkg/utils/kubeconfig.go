@@ -0,0 +1,64 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package utils
+
+import (
+	"fmt"
+	"os/exec"
+
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+)
+
+// NewKubeConfig builds a rest.Config from a kubeconfig file, with full
+// support for OIDC and exec credential plugins (e.g. `aws eks
+// get-token`, ROSA's `ocm` tokens). client-go's exec provider refreshes
+// expired credentials transparently mid-run, so long suites keep working
+// past the first token expiry; this helper's job is to fail at setup
+// time, with a useful message, when the plugin the kubeconfig names is
+// not usable in this environment.
+func NewKubeConfig(path string) (*rest.Config, error) {
+	loadingRules := &clientcmd.ClientConfigLoadingRules{ExplicitPath: path}
+	clientConfig := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(loadingRules, &clientcmd.ConfigOverrides{})
+
+	rawConfig, err := clientConfig.RawConfig()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load kubeconfig %s: %w", path, err)
+	}
+	if err := validateAuthProviders(path, &rawConfig); err != nil {
+		return nil, err
+	}
+
+	restConfig, err := clientConfig.ClientConfig()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build rest config from %s: %w", path, err)
+	}
+	if restConfig.ExecProvider != nil {
+		// Suites run headless; a plugin prompting for input would hang
+		// the run instead of failing it.
+		restConfig.ExecProvider.InteractiveMode = clientcmdapi.NeverExecInteractiveMode
+	}
+	return restConfig, nil
+}
+
+// validateAuthProviders checks every exec plugin the kubeconfig
+// references is actually on PATH, and rejects the legacy auth-provider
+// stanzas client-go no longer ships built-in support for (except oidc,
+// which still works through the token refresh flow).
+func validateAuthProviders(path string, rawConfig *clientcmdapi.Config) error {
+	for name, authInfo := range rawConfig.AuthInfos {
+		if authInfo.Exec != nil {
+			if _, err := exec.LookPath(authInfo.Exec.Command); err != nil {
+				return fmt.Errorf("kubeconfig %s user %s needs credential plugin %q which is not on PATH: %w",
+					path, name, authInfo.Exec.Command, err)
+			}
+			continue
+		}
+		if authInfo.AuthProvider != nil && authInfo.AuthProvider.Name != "oidc" {
+			return fmt.Errorf("kubeconfig %s user %s uses unsupported auth provider %q; migrate it to an exec credential plugin",
+				path, name, authInfo.AuthProvider.Name)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,93 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package utils
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+// RetryPolicy governs retries of hub API calls that fail transiently.
+// The lifecycle helpers share one policy instead of hand-rolling
+// conflict loops.
+type RetryPolicy struct {
+	// MaxAttempts bounds the total tries. Defaults to 5.
+	MaxAttempts int
+	// Backoff schedules the delay between attempts; its Timeout field is
+	// ignored here — MaxAttempts is the bound.
+	Backoff BackoffOptions
+}
+
+// DefaultRetryPolicy suits normal hub load: five attempts over roughly
+// fifteen seconds.
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts: 5,
+	Backoff: BackoffOptions{
+		Initial: 500 * time.Millisecond,
+		Max:     8 * time.Second,
+		Factor:  2,
+		Jitter:  0.2,
+	},
+}
+
+// IsTransientAPIError reports whether the error is worth retrying:
+// optimistic-concurrency conflicts, API priority throttling, server
+// timeouts, and admission webhook timeouts (which the API server wraps
+// as internal errors).
+func IsTransientAPIError(err error) bool {
+	switch {
+	case err == nil:
+		return false
+	case apierrors.IsConflict(err),
+		apierrors.IsTooManyRequests(err),
+		apierrors.IsServerTimeout(err),
+		apierrors.IsTimeout(err),
+		apierrors.IsServiceUnavailable(err):
+		return true
+	case apierrors.IsInternalError(err) && strings.Contains(err.Error(), "failed calling webhook"):
+		return true
+	default:
+		return false
+	}
+}
+
+// RetryOnTransient runs fn, retrying transient API errors under the
+// policy. Permanent errors and context cancellation return immediately;
+// exhausting the attempts returns the last error annotated with the
+// attempt count.
+func RetryOnTransient(ctx context.Context, policy RetryPolicy, fn func(ctx context.Context) error) error {
+	maxAttempts := policy.MaxAttempts
+	if maxAttempts <= 0 {
+		maxAttempts = DefaultRetryPolicy.MaxAttempts
+	}
+	backoff := policy.Backoff.withDefaults()
+
+	var lastErr error
+	interval := backoff.Initial
+	for attempt := 1; attempt <= maxAttempts; attempt++ {
+		lastErr = fn(ctx)
+		if lastErr == nil {
+			return nil
+		}
+		if !IsTransientAPIError(lastErr) {
+			return lastErr
+		}
+		if attempt == maxAttempts {
+			break
+		}
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("retry aborted after attempt %d: %w", attempt, context.Cause(ctx))
+		case <-time.After(jittered(interval, backoff.Jitter)):
+		}
+		interval = time.Duration(float64(interval) * backoff.Factor)
+		if interval > backoff.Max {
+			interval = backoff.Max
+		}
+	}
+	return fmt.Errorf("still failing after %d attempts: %w", maxAttempts, lastErr)
+}
@@ -0,0 +1,119 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package utils
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+var testResource = schema.GroupResource{Group: "cluster.open-cluster-management.io", Resource: "managedclusters"}
+
+func TestIsTransientAPIError(t *testing.T) {
+	cases := []struct {
+		name      string
+		err       error
+		transient bool
+	}{
+		{name: "nil", err: nil, transient: false},
+		{name: "conflict", err: apierrors.NewConflict(testResource, "c1", errors.New("modified")), transient: true},
+		{name: "throttled", err: apierrors.NewTooManyRequests("slow down", 1), transient: true},
+		{name: "server timeout", err: apierrors.NewServerTimeout(testResource, "get", 1), transient: true},
+		{name: "timeout", err: apierrors.NewTimeoutError("deadline", 1), transient: true},
+		{name: "unavailable", err: apierrors.NewServiceUnavailable("etcd down"), transient: true},
+		{
+			name:      "webhook timeout",
+			err:       apierrors.NewInternalError(errors.New(`failed calling webhook "cluster.validating": timeout`)),
+			transient: true,
+		},
+		{name: "other internal", err: apierrors.NewInternalError(errors.New("index corruption")), transient: false},
+		{name: "not found", err: apierrors.NewNotFound(testResource, "c1"), transient: false},
+		{name: "plain error", err: errors.New("dial tcp: connection refused"), transient: false},
+		{
+			name:      "wrapped conflict",
+			err:       fmt.Errorf("failed to update: %w", apierrors.NewConflict(testResource, "c1", errors.New("modified"))),
+			transient: true,
+		},
+	}
+	for _, testcase := range cases {
+		t.Run(testcase.name, func(t *testing.T) {
+			if got := IsTransientAPIError(testcase.err); got != testcase.transient {
+				t.Errorf("IsTransientAPIError(%v) = %v, want %v", testcase.err, got, testcase.transient)
+			}
+		})
+	}
+}
+
+// fastPolicy keeps retry tests quick without changing the semantics.
+var fastPolicy = RetryPolicy{
+	MaxAttempts: 3,
+	Backoff:     BackoffOptions{Initial: time.Millisecond, Max: 2 * time.Millisecond, Jitter: -1},
+}
+
+func TestRetryOnTransientEventualSuccess(t *testing.T) {
+	attempts := 0
+	err := RetryOnTransient(context.Background(), fastPolicy, func(ctx context.Context) error {
+		attempts++
+		if attempts < 3 {
+			return apierrors.NewConflict(testResource, "c1", errors.New("modified"))
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("RetryOnTransient returned %v, want success", err)
+	}
+	if attempts != 3 {
+		t.Errorf("fn ran %d times, want 3", attempts)
+	}
+}
+
+func TestRetryOnTransientPermanentError(t *testing.T) {
+	permanent := apierrors.NewNotFound(testResource, "c1")
+	attempts := 0
+	err := RetryOnTransient(context.Background(), fastPolicy, func(ctx context.Context) error {
+		attempts++
+		return permanent
+	})
+	if !errors.Is(err, permanent) {
+		t.Fatalf("RetryOnTransient returned %v, want %v unretried", err, permanent)
+	}
+	if attempts != 1 {
+		t.Errorf("fn ran %d times for a permanent error, want 1", attempts)
+	}
+}
+
+func TestRetryOnTransientExhaustsAttempts(t *testing.T) {
+	conflict := apierrors.NewConflict(testResource, "c1", errors.New("modified"))
+	attempts := 0
+	err := RetryOnTransient(context.Background(), fastPolicy, func(ctx context.Context) error {
+		attempts++
+		return conflict
+	})
+	if attempts != fastPolicy.MaxAttempts {
+		t.Errorf("fn ran %d times, want %d", attempts, fastPolicy.MaxAttempts)
+	}
+	if !errors.Is(err, conflict) {
+		t.Fatalf("exhaustion error %v does not wrap the last failure", err)
+	}
+	if !strings.Contains(err.Error(), "3 attempts") {
+		t.Errorf("exhaustion error %q does not report the attempt count", err)
+	}
+}
+
+func TestRetryOnTransientCancelledContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err := RetryOnTransient(ctx, fastPolicy, func(ctx context.Context) error {
+		return apierrors.NewConflict(testResource, "c1", errors.New("modified"))
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("RetryOnTransient under cancelled context returned %v, want context.Canceled", err)
+	}
+}
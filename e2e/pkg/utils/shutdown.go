@@ -0,0 +1,105 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package utils
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// shutdownGrace bounds how long cleanup may run after a termination
+// signal; CI systems send SIGKILL shortly after SIGTERM.
+const shutdownGrace = 5 * time.Minute
+
+// Shutdown coordinates graceful termination. On SIGINT/SIGTERM it
+// cancels the suite context (aborting in-flight applier operations),
+// runs the registered cleanups in reverse order — deleting half-created
+// clusters, flushing reports — and exits non-zero. A second signal skips
+// cleanup and exits immediately.
+type Shutdown struct {
+	cancel context.CancelCauseFunc
+
+	mu       sync.Mutex
+	cleanups []namedCleanup
+}
+
+type namedCleanup struct {
+	name string
+	fn   func(ctx context.Context) error
+}
+
+// NewShutdown derives the suite context from parent and starts listening
+// for termination signals.
+func NewShutdown(parent context.Context) (context.Context, *Shutdown) {
+	ctx, cancel := context.WithCancelCause(parent)
+	shutdown := &Shutdown{cancel: cancel}
+
+	signals := make(chan os.Signal, 2)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+	go func() {
+		received := <-signals
+		fmt.Fprintf(os.Stderr, "received %s, cancelling suite and running cleanup (repeat to force exit)\n", received)
+		cancel(fmt.Errorf("received %s", received))
+
+		done := make(chan struct{})
+		go func() {
+			shutdown.runCleanups()
+			close(done)
+		}()
+		select {
+		case <-done:
+		case <-signals:
+			fmt.Fprintln(os.Stderr, "second signal, exiting without finishing cleanup")
+		case <-time.After(shutdownGrace):
+			fmt.Fprintf(os.Stderr, "cleanup did not finish within %s, exiting\n", shutdownGrace)
+		}
+		os.Exit(1)
+	}()
+	return ctx, shutdown
+}
+
+// Register adds a cleanup to run on termination. Suites register the
+// expensive reversals here as they create resources (cluster deletions,
+// report flushes); cleanups run last-registered first, mirroring
+// deferred teardown order.
+func (s *Shutdown) Register(name string, fn func(ctx context.Context) error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.cleanups = append(s.cleanups, namedCleanup{name: name, fn: fn})
+}
+
+// Deregister removes a cleanup once its resource was torn down normally.
+func (s *Shutdown) Deregister(name string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i := len(s.cleanups) - 1; i >= 0; i-- {
+		if s.cleanups[i].name == name {
+			s.cleanups = append(s.cleanups[:i], s.cleanups[i+1:]...)
+			return
+		}
+	}
+}
+
+func (s *Shutdown) runCleanups() {
+	s.mu.Lock()
+	cleanups := make([]namedCleanup, len(s.cleanups))
+	copy(cleanups, s.cleanups)
+	s.mu.Unlock()
+
+	// The suite context is already cancelled; cleanups get their own
+	// bounded context so they can still talk to the hub.
+	ctx, cancel := context.WithTimeout(context.Background(), shutdownGrace)
+	defer cancel()
+	for i := len(cleanups) - 1; i >= 0; i-- {
+		cleanup := cleanups[i]
+		fmt.Fprintf(os.Stderr, "cleanup: %s\n", cleanup.name)
+		if err := cleanup.fn(ctx); err != nil {
+			fmt.Fprintf(os.Stderr, "cleanup %s failed: %v\n", cleanup.name, err)
+		}
+	}
+}